@@ -0,0 +1,11 @@
+# Resolve the policies applied to a quorum queue
+data "rabbitmq_effective_policy" "example" {
+  vhost      = "myvhost"
+  name       = "orders.eu"
+  kind       = "queue"
+  queue_type = "quorum"
+}
+
+output "message_ttl" {
+  value = lookup(data.rabbitmq_effective_policy.example.definition, "message-ttl", null)
+}
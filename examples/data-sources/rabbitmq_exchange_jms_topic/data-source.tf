@@ -0,0 +1,5 @@
+# Read the exchange settings
+data "rabbitmq_exchange_jms_topic" "example" {
+  name  = "myexchange"
+  vhost = "myvhost"
+}
@@ -0,0 +1,5 @@
+# Read the exchange settings
+data "rabbitmq_exchange_local_random" "example" {
+  name  = "myexchange"
+  vhost = "myvhost"
+}
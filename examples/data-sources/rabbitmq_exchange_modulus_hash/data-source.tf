@@ -0,0 +1,5 @@
+# Read the exchange settings
+data "rabbitmq_exchange_modulus_hash" "example" {
+  name  = "myexchange"
+  vhost = "myvhost"
+}
@@ -0,0 +1,5 @@
+# Read the exchange settings
+data "rabbitmq_exchange_recent_history" "example" {
+  name  = "myexchange"
+  vhost = "myvhost"
+}
@@ -0,0 +1,8 @@
+data "rabbitmq_federation_links" "example" {
+  vhost    = "myvhost"
+  upstream = "myfederationupstream"
+}
+
+output "failed_links" {
+  value = [for l in data.rabbitmq_federation_links.example.links : l.error if l.status == "error"]
+}
@@ -0,0 +1,7 @@
+data "rabbitmq_global_parameter" "cluster_name" {
+  name = "cluster_name"
+}
+
+output "cluster_name" {
+  value = jsondecode(data.rabbitmq_global_parameter.cluster_name.value)
+}
@@ -0,0 +1,9 @@
+data "rabbitmq_parameter" "upstreams" {
+  component = "federation-upstream-set"
+  name      = "all-regions"
+  vhost     = "test"
+}
+
+output "upstreams" {
+  value = jsondecode(data.rabbitmq_parameter.upstreams.value)
+}
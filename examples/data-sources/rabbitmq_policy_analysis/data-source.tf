@@ -0,0 +1,8 @@
+# Analyze the policies of a vhost
+data "rabbitmq_policy_analysis" "example" {
+  vhost = "myvhost"
+}
+
+output "policy_conflicts" {
+  value = [for w in data.rabbitmq_policy_analysis.example.warnings : w.message]
+}
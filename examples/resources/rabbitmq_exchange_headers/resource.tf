@@ -16,4 +16,10 @@ resource "rabbitmq_exchange_headers" "example" {
     value = "12345"
     type  = "numeric"
   }
+
+  argument {
+    key   = "myListKey"
+    value = "myString, 12345, true"
+    type  = "list"
+  }
 }
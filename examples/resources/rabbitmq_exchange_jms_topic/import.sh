@@ -0,0 +1,2 @@
+# Exchange can be imported by specifying its name and its vhost (with a '@' between the both value).
+terraform import rabbitmq_exchange_jms_topic.example myexchange@myvhost
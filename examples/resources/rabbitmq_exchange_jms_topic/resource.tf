@@ -0,0 +1,30 @@
+# Create a vhost
+resource "rabbitmq_vhost" "example" {
+  name = "myvhost"
+}
+
+# Create an exchange
+resource "rabbitmq_exchange_jms_topic" "example" {
+  name  = "myexchange"
+  vhost = rabbitmq_vhost.example.name
+}
+
+# Bind a queue with a selector of the messages
+resource "rabbitmq_queue" "example" {
+  name  = "myqueue"
+  vhost = rabbitmq_vhost.example.name
+
+  settings {
+    durable     = true
+    auto_delete = false
+  }
+}
+
+resource "rabbitmq_binding" "example" {
+  source             = rabbitmq_exchange_jms_topic.example.name
+  vhost              = rabbitmq_vhost.example.name
+  destination        = rabbitmq_queue.example.name
+  destination_type   = "queue"
+  routing_key        = "orders.#"
+  jms_topic_selector = "{'=', {'ident', <<\"type\">>}, <<\"order\">>}"
+}
@@ -0,0 +1,28 @@
+# Create a vhost
+resource "rabbitmq_vhost" "example" {
+  name = "myvhost"
+}
+
+# Create an exchange routing the requests to the queues of the node
+resource "rabbitmq_exchange_local_random" "example" {
+  name  = "myrequests"
+  vhost = rabbitmq_vhost.example.name
+}
+
+# Bind a queue without routing key
+resource "rabbitmq_queue" "example" {
+  name  = "myservice"
+  vhost = rabbitmq_vhost.example.name
+
+  settings {
+    durable     = false
+    auto_delete = true
+  }
+}
+
+resource "rabbitmq_binding" "example" {
+  source           = rabbitmq_exchange_local_random.example.name
+  vhost            = rabbitmq_vhost.example.name
+  destination      = rabbitmq_queue.example.name
+  destination_type = "queue"
+}
@@ -0,0 +1,12 @@
+# Create a vhost
+resource "rabbitmq_vhost" "example" {
+  name = "myvhost"
+}
+
+# Create an exchange
+resource "rabbitmq_exchange_modulus_hash" "example" {
+  name  = "myexchange"
+  vhost = rabbitmq_vhost.example.name
+
+  durable = true
+}
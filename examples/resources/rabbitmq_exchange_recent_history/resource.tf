@@ -0,0 +1,12 @@
+# Create a vhost
+resource "rabbitmq_vhost" "example" {
+  name = "myvhost"
+}
+
+# Create an exchange keeping the 50 latest messages
+resource "rabbitmq_exchange_recent_history" "example" {
+  name  = "myexchange"
+  vhost = rabbitmq_vhost.example.name
+
+  history_length = 50
+}
@@ -0,0 +1,2 @@
+# Federation policies can be imported using the `id` which is composed of `name@vhost`.
+terraform import rabbitmq_federation.example federate-events@myvhost
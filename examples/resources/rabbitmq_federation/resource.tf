@@ -0,0 +1,33 @@
+# Federate the exchanges matching a pattern from all the upstreams of a set
+resource "rabbitmq_federation" "example" {
+  name         = "federate-events"
+  vhost        = "myvhost"
+  pattern      = "^events\\."
+  apply_to     = "exchanges"
+  upstream_set = "regions"
+}
+
+# Federate the queues matching a pattern from a single upstream
+resource "rabbitmq_federation" "queues" {
+  name     = "federate-jobs"
+  vhost    = "myvhost"
+  pattern  = "^jobs\\."
+  apply_to = "queues"
+  priority = 10
+  upstream = "eu"
+}
+
+# Federate the exchanges matching a pattern and wait for the links to be running
+resource "rabbitmq_federation" "checked" {
+  name     = "federate-orders"
+  vhost    = "myvhost"
+  pattern  = "^orders\\."
+  apply_to = "exchanges"
+  upstream = "eu"
+
+  wait_for_links_running = true
+
+  timeouts {
+    create = "5m"
+  }
+}
@@ -0,0 +1,2 @@
+# Federation upstream sets can be imported using the `id` which is composed of `name@vhost`.
+terraform import rabbitmq_federation_upstream_set.example regions@myvhost
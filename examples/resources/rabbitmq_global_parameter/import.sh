@@ -0,0 +1,2 @@
+# Global parameters can be imported using their name.
+terraform import rabbitmq_global_parameter.cluster_name cluster_name
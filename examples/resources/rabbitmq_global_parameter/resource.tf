@@ -0,0 +1,14 @@
+# Set the name of the cluster
+resource "rabbitmq_global_parameter" "cluster_name" {
+  name  = "cluster_name"
+  value = jsonencode("production")
+}
+
+# Map the MQTT listener ports to vhosts
+resource "rabbitmq_global_parameter" "mqtt_port_to_vhost_mapping" {
+  name = "mqtt_port_to_vhost_mapping"
+  value = jsonencode({
+    "1883" = "vhost1"
+    "8883" = "vhost2"
+  })
+}
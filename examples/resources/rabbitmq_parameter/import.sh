@@ -0,0 +1,2 @@
+# Parameters can be imported using the `id` which is composed of `component/name@vhost`.
+terraform import rabbitmq_parameter.upstreams federation-upstream-set/all-regions@test
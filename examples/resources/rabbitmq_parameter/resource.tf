@@ -0,0 +1,14 @@
+resource "rabbitmq_vhost" "test" {
+  name = "test"
+}
+
+# A set of federation upstreams
+resource "rabbitmq_parameter" "upstreams" {
+  component = "federation-upstream-set"
+  name      = "all-regions"
+  vhost     = rabbitmq_vhost.test.name
+  value = jsonencode([
+    { upstream = "eu" },
+    { upstream = "us" },
+  ])
+}
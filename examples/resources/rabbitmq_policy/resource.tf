@@ -37,3 +37,23 @@ resource "rabbitmq_policy" "example" {
     }
   }
 }
+
+# Create a policy with typed attributes and a JSON definition
+resource "rabbitmq_policy" "example_typed" {
+  name  = "mytypedpolicy"
+  vhost = rabbitmq_permissions.example.vhost
+
+  policy {
+    pattern  = "^orders\\."
+    priority = 1
+    apply_to = "queues"
+
+    max_length           = 10000
+    dead_letter_exchange = "orders.dlx"
+
+    definition_json = jsonencode({
+      overflow         = "reject-publish"
+      consumer-timeout = 3600000
+    })
+  }
+}
@@ -29,13 +29,87 @@ resource "rabbitmq_shovel" "example" {
 	name = "myshovel"
 	vhost = rabbitmq_vhost.example.name
 	info {
-		source_uri = "amqp:///example"
+		source_uris = ["amqp:///example"]
 		source_exchange = rabbitmq_exchange.example.name
 		source_exchange_key = "example"
-		destination_uri = "amqp:///example"
+		destination_uris = ["amqp:///example"]
 		destination_queue = rabbitmq_queue.example.name
 		destination_queue_arguments = {
 			x-queue-type = "classic"
 		}
 	}
 }
+
+# Create a shovel to a remote broker, the uris are assembled from the connections
+resource "rabbitmq_shovel" "remote" {
+	name = "myremoteshovel"
+	vhost = rabbitmq_vhost.example.name
+	info {
+		source_connection {
+			hosts = ["localhost"]
+			vhost = rabbitmq_vhost.example.name
+			username = "guest"
+			password = "guest"
+		}
+		source_queue = rabbitmq_queue.example.name
+		destination_connection {
+			hosts = ["remote-a", "remote-b"]
+			port = 5671
+			vhost = "/"
+			username = "shovel"
+			password = "shovel-password"
+			tls = true
+			cacertfile = "/etc/rabbitmq/ca.pem"
+			verify = "verify_peer"
+			heartbeat = 30
+		}
+		destination_queue = "myremotequeue"
+	}
+}
+
+# Create a shovel and wait for it to be running
+resource "rabbitmq_shovel" "checked" {
+	name = "mycheckedshovel"
+	vhost = rabbitmq_vhost.example.name
+	wait_for_running = true
+	info {
+		source_uris = ["amqp:///example"]
+		source_queue = rabbitmq_queue.example.name
+		destination_uris = ["amqp:///example"]
+		destination_exchange = rabbitmq_exchange.example.name
+	}
+
+	timeouts {
+		create = "5m"
+	}
+}
+
+# Create a shovel from an AMQP 1.0 broker
+resource "rabbitmq_shovel" "amqp10" {
+	name = "myamqp10shovel"
+	vhost = rabbitmq_vhost.example.name
+	info {
+		source_protocol = "amqp10"
+		source_uris = ["amqp://amqp10-broker:5672"]
+		source_address = "/queues/source"
+		source_delete_after = "queue-length"
+		destination_uris = ["amqp:///example"]
+		destination_queue = rabbitmq_queue.example.name
+	}
+}
+
+# Move the messages of a queue once, change the restart trigger to move them again
+resource "rabbitmq_shovel" "move" {
+	name = "mymoveshovel"
+	vhost = rabbitmq_vhost.example.name
+	restart_triggers = {
+		run = "1"
+	}
+	info {
+		source_uris = ["amqp:///example"]
+		source_queue = rabbitmq_queue.example.name
+		source_delete_after = "queue-length"
+		destination_uris = ["amqp:///example"]
+		destination_queue = "mynewqueue"
+	}
+}
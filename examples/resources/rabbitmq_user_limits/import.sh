@@ -0,0 +1,2 @@
+# User limits can be imported by specifying the user name.
+terraform import rabbitmq_user_limits.example myuser
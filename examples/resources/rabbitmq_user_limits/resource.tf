@@ -0,0 +1,12 @@
+# Create a user
+resource "rabbitmq_user" "example" {
+  name     = "myuser"
+  password = "foobar"
+}
+
+# Limit the connections and the channels of the user
+resource "rabbitmq_user_limits" "example" {
+  user            = rabbitmq_user.example.name
+  max_connections = 10
+  max_channels    = -1
+}
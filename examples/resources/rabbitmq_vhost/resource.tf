@@ -2,3 +2,10 @@
 resource "rabbitmq_vhost" "example" {
   name = "myvhost"
 }
+
+# Create a tagged virtual host which can't be destroyed while its queues have messages
+resource "rabbitmq_vhost" "orders" {
+  name                         = "orders"
+  tags                         = ["production"]
+  prevent_destroy_if_not_empty = true
+}
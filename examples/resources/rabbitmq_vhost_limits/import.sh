@@ -0,0 +1,2 @@
+# Vhost limits can be imported by specifying the vhost name.
+terraform import rabbitmq_vhost_limits.example myvhost
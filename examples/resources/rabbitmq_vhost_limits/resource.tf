@@ -0,0 +1,11 @@
+# Create a virtual host
+resource "rabbitmq_vhost" "example" {
+  name = "myvhost"
+}
+
+# Limit the connections and the queues of the virtual host
+resource "rabbitmq_vhost_limits" "example" {
+  vhost           = rabbitmq_vhost.example.name
+  max_connections = 100
+  max_queues      = 50
+}
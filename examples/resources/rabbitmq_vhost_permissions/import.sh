@@ -0,0 +1,5 @@
+# Vhost permissions can be imported by specifying the vhost name.
+terraform import rabbitmq_vhost_permissions.example myvhost
+
+# The ignored users are not stored by RabbitMQ, they can be given before the vhost name.
+terraform import rabbitmq_vhost_permissions.example admin,monitoring@myvhost
@@ -0,0 +1,23 @@
+# Create a vhost
+resource "rabbitmq_vhost" "example" {
+  name = "myvhost"
+}
+
+# Create a user
+resource "rabbitmq_user" "example" {
+  name     = "myuser"
+  password = "foobar"
+}
+
+# Only 'myuser' can access the vhost, the permissions of the other users are revoked (except the 'admin' user)
+resource "rabbitmq_vhost_permissions" "example" {
+  vhost        = rabbitmq_vhost.example.name
+  ignore_users = ["admin"]
+
+  permissions {
+    user      = rabbitmq_user.example.name
+    configure = ".*"
+    write     = ".*"
+    read      = ".*"
+  }
+}
@@ -48,9 +48,18 @@ func (t thatType) ExistsInRabbitMQ(r interface{}) resource.TestCheckFunc {
 		if strings.HasPrefix(t.resourceName, "rabbitmq_user.") {
 			return r.(acceptance.UserResource).ExistsInRabbitMQ()
 		}
+		if strings.HasPrefix(t.resourceName, "rabbitmq_user_limits.") {
+			return r.(acceptance.UserLimitsResource).ExistsInRabbitMQ()
+		}
 		if strings.HasPrefix(t.resourceName, "rabbitmq_vhost.") {
 			return r.(acceptance.VhostResource).ExistsInRabbitMQ()
 		}
+		if strings.HasPrefix(t.resourceName, "rabbitmq_vhost_limits.") {
+			return r.(acceptance.VhostLimitsResource).ExistsInRabbitMQ()
+		}
+		if strings.HasPrefix(t.resourceName, "rabbitmq_vhost_permissions.") {
+			return r.(acceptance.VhostPermissionsResource).ExistsInRabbitMQ()
+		}
 		if strings.HasPrefix(t.resourceName, "rabbitmq_queue.") {
 			return r.(acceptance.QueueResource).ExistsInRabbitMQ()
 		}
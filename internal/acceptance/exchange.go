@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider"
 )
 
 type ExchangeResource struct {
@@ -134,7 +135,7 @@ func (q *ExchangeResource) DataSource(data TestData) string {
 
 func (e ExchangeResource) ExistsInRabbitMQ() error {
 
-	rmqc := TestAcc.Provider.Meta().(*rabbithole.Client)
+	rmqc := TestAcc.Provider.Meta().(*provider.Meta).Client
 	myExchange, err := rmqc.GetExchange(e.Vhost, e.Name)
 	if err != nil {
 		return fmt.Errorf("error retrieving exchange '%s': %#v", e.Name, err)
@@ -186,7 +187,7 @@ func (e ExchangeResource) ExistsInRabbitMQ() error {
 
 func (e *ExchangeResource) CheckDestroy() resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := TestAcc.Provider.Meta().(*rabbithole.Client)
+		rmqc := TestAcc.Provider.Meta().(*provider.Meta).Client
 		exchange, err := rmqc.GetExchange(e.Vhost, e.Name)
 		if err != nil && err.(rabbithole.ErrorResponse).StatusCode != 404 {
 			return fmt.Errorf("error retrieving exchange '%s': %#v", e.Name, err)
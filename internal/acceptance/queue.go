@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider"
 	"golang.org/x/mod/semver"
 )
 
@@ -162,7 +163,7 @@ func (q *QueueResource) DataSource(data TestData) string {
 }
 
 func (q QueueResource) ExistsInRabbitMQ() error {
-	rmqc := TestAcc.Provider.Meta().(*rabbithole.Client)
+	rmqc := TestAcc.Provider.Meta().(*provider.Meta).Client
 	myQueue, err := rmqc.GetQueue(q.Vhost, q.Name)
 
 	if err != nil {
@@ -201,7 +202,7 @@ func (q QueueResource) ExistsInRabbitMQ() error {
 
 func (q QueueResource) CheckQueueTypeInRabbitMQ(queue_type string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := TestAcc.Provider.Meta().(*rabbithole.Client)
+		rmqc := TestAcc.Provider.Meta().(*provider.Meta).Client
 		myQueue, err := rmqc.GetQueue(q.Vhost, q.Name)
 		if err != nil {
 			return fmt.Errorf("error retrieving queue '%s@%s': %#v", q.Name, q.Vhost, err)
@@ -215,7 +216,7 @@ func (q QueueResource) CheckQueueTypeInRabbitMQ(queue_type string) resource.Test
 
 func (q QueueResource) CheckDestroy() resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := TestAcc.Provider.Meta().(*rabbithole.Client)
+		rmqc := TestAcc.Provider.Meta().(*provider.Meta).Client
 		vhost, err := rmqc.GetQueue(q.Vhost, q.Name)
 		if err != nil && err.(rabbithole.ErrorResponse).StatusCode != 404 {
 			return fmt.Errorf("error retrieving queue '%s@%s': %#v", q.Name, q.Vhost, err)
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider"
 )
 
 type UserResource struct {
@@ -79,20 +80,26 @@ func (u *UserResource) LoginUpdate(data TestData) string {
 	return u.LoginCreate(data)
 }
 
-func (u *UserResource) ErrorConvertingCreate(data TestData) string {
+func (u *UserResource) LimitsCreate(data TestData) string {
+	limits := ""
+	if u.MaxConnections != "" {
+		limits += fmt.Sprintf("\n\t\tmax_connections = \"%s\"", u.MaxConnections)
+	}
+	if u.MaxChannels != "" {
+		limits += fmt.Sprintf("\n\t\tmax_channels = \"%s\"", u.MaxChannels)
+	}
+
 	return fmt.Sprintf(`
 	resource "%s" "%s" {
 		name = "%s"
-		password = "%s"
-		max_connections = "%s"
-		max_channels = "%s"
-	}`, data.ResourceType, data.ResourceLabel, u.Name, u.Password, u.MaxConnections, u.MaxChannels)
+		password = "%s"%s
+	}`, data.ResourceType, data.ResourceLabel, u.Name, u.Password, limits)
 }
 
-func (u *UserResource) ErrorConvertingUpdate(data TestData, connections string, channels string) string {
+func (u *UserResource) LimitsUpdate(data TestData, connections string, channels string) string {
 	u.MaxConnections = connections
 	u.MaxChannels = channels
-	return u.ErrorConvertingCreate(data)
+	return u.LimitsCreate(data)
 }
 
 func (u *UserResource) DataSource(data TestData) string {
@@ -118,7 +125,7 @@ func (u *UserResource) DataSourceLimits(data TestData) string {
 
 func (u UserResource) ExistsInRabbitMQ() error {
 
-	rmqc := TestAcc.Provider.Meta().(*rabbithole.Client)
+	rmqc := TestAcc.Provider.Meta().(*provider.Meta).Client
 	myUser, err := rmqc.GetUser(u.Name)
 	if err != nil {
 		return fmt.Errorf("error retrieving user '%s': %#v", u.Name, err)
@@ -184,7 +191,7 @@ func (u UserResource) CheckLoginInRabbitMQ() resource.TestCheckFunc {
 
 func (u UserResource) CheckDestroy() resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := TestAcc.Provider.Meta().(*rabbithole.Client)
+		rmqc := TestAcc.Provider.Meta().(*provider.Meta).Client
 		user, err := rmqc.GetUser(u.Name)
 		if err != nil && err.(rabbithole.ErrorResponse).StatusCode != 404 {
 			return fmt.Errorf("error retrieving user '%s': %#v", u.Name, err)
@@ -0,0 +1,125 @@
+package acceptance
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider"
+)
+
+type UserLimitsResource struct {
+	User           string
+	Password       string
+	MaxConnections string
+	MaxChannels    string
+}
+
+func (u *UserLimitsResource) user() string {
+	return fmt.Sprintf(`
+	resource "rabbitmq_user" "test" {
+		name = "%s"
+		password = "%s"
+	}`, u.User, u.Password)
+}
+
+func (u *UserLimitsResource) Create(data TestData) string {
+	limits := ""
+	if u.MaxConnections != "" {
+		limits += fmt.Sprintf("\n\t\tmax_connections = %s", u.MaxConnections)
+	}
+	if u.MaxChannels != "" {
+		limits += fmt.Sprintf("\n\t\tmax_channels = %s", u.MaxChannels)
+	}
+
+	return u.user() + fmt.Sprintf(`
+	resource "%s" "%s" {
+		user = rabbitmq_user.test.name%s
+	}`, data.ResourceType, data.ResourceLabel, limits)
+}
+
+func (u *UserLimitsResource) Update(data TestData) string {
+	u.MaxConnections = data.RandomIntegerString()
+	u.MaxChannels = "-1"
+	return u.Create(data)
+}
+
+func (u *UserLimitsResource) RemoveOne(data TestData) string {
+	u.MaxChannels = ""
+	return u.Create(data)
+}
+
+func (u *UserLimitsResource) ConflictWithUser(data TestData) string {
+	return fmt.Sprintf(`
+	resource "rabbitmq_user" "test" {
+		name = "%s"
+		password = "%s"
+		max_connections = "%s"
+	}
+
+	resource "%s" "%s" {
+		user = rabbitmq_user.test.name
+		max_connections = %s
+	}`, u.User, u.Password, u.MaxConnections, data.ResourceType, data.ResourceLabel, u.MaxConnections)
+}
+
+func (u UserLimitsResource) ExistsInRabbitMQ() error {
+	rmqc := TestAcc.Provider.Meta().(*provider.Meta).Client
+
+	myUserLimits, err := rmqc.GetUserLimits(u.User)
+	if err != nil {
+		return fmt.Errorf("error retrieving user limit '%s': %#v", u.User, err)
+	}
+
+	values := rabbithole.UserLimitsValues{}
+	if len(myUserLimits) > 0 {
+		values = myUserLimits[0].Value
+	}
+
+	if err := checkLimit(values, "max-connections", u.MaxConnections); err != nil {
+		return fmt.Errorf("user %s", err)
+	}
+	if err := checkLimit(values, "max-channels", u.MaxChannels); err != nil {
+		return fmt.Errorf("user %s", err)
+	}
+
+	return nil
+}
+
+func (u UserLimitsResource) CheckDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := TestAcc.Provider.Meta().(*provider.Meta).Client
+		myUserLimits, err := rmqc.GetUserLimits(u.User)
+		if err != nil && err.(rabbithole.ErrorResponse).StatusCode != 404 {
+			return fmt.Errorf("error retrieving user limit '%s': %#v", u.User, err)
+		}
+
+		if len(myUserLimits) > 0 && len(myUserLimits[0].Value) > 0 {
+			return fmt.Errorf("user limits still exist: %#v", myUserLimits[0].Value)
+		}
+
+		return nil
+	}
+}
+
+// check the value of a limit, an empty expected value means the limit is not set
+func checkLimit(values map[string]int, key string, expected string) error {
+	val, ok := values[key]
+	if expected == "" {
+		if ok {
+			return fmt.Errorf("limit '%s' is set. Actual: '%d' Expected: not set", key, val)
+		}
+		return nil
+	}
+
+	if !ok {
+		return fmt.Errorf("limit '%s' is not set. Expected: %s", key, expected)
+	}
+	if strconv.Itoa(val) != expected {
+		return fmt.Errorf("limit '%s' is not equal. Actual: '%d' Expected: %s", key, val, expected)
+	}
+
+	return nil
+}
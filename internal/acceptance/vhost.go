@@ -2,12 +2,18 @@ package acceptance
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider"
 )
 
 type VhostResource struct {
@@ -17,6 +23,7 @@ type VhostResource struct {
 	MaxConnections   string
 	MaxQueues        string
 	Tracing          bool
+	Tags             []string
 }
 
 func (v *VhostResource) RequiredCreate(data TestData) string {
@@ -109,19 +116,54 @@ func (v *VhostResource) OptionalRemove(data TestData) string {
 
 }
 
-func (v *VhostResource) ErrorConvertingCreate(data TestData) string {
+func (v *VhostResource) LimitsCreate(data TestData) string {
+	limits := ""
+	if v.MaxConnections != "" {
+		limits += fmt.Sprintf("\n\t\tmax_connections = \"%s\"", v.MaxConnections)
+	}
+	if v.MaxQueues != "" {
+		limits += fmt.Sprintf("\n\t\tmax_queues = \"%s\"", v.MaxQueues)
+	}
+
+	return fmt.Sprintf(`
+	resource "%s" "%s" {
+		name = "%s"%s
+	}`, data.ResourceType, data.ResourceLabel, v.Name, limits)
+}
+
+func (v *VhostResource) LimitsUpdate(data TestData, connections string, queues string) string {
+	v.MaxConnections = connections
+	v.MaxQueues = queues
+	return v.LimitsCreate(data)
+}
+
+func (v *VhostResource) TagsCreate(data TestData) string {
 	return fmt.Sprintf(`
 	resource "%s" "%s" {
 		name = "%s"
-		max_connections = "%s"
-		max_queues = "%s"
-	}`, data.ResourceType, data.ResourceLabel, v.Name, v.MaxConnections, v.MaxQueues)
+		tags = %s
+	}`, data.ResourceType, data.ResourceLabel, v.Name, data.BuildArrayString(v.Tags))
 }
 
-func (u *VhostResource) ErrorConvertingUpdate(data TestData, connections string, queues string) string {
-	u.MaxConnections = connections
-	u.MaxQueues = queues
-	return u.ErrorConvertingCreate(data)
+func (v *VhostResource) TagsUpdate(data TestData) string {
+	v.Tags = []string{data.RandomString()}
+	return v.TagsCreate(data)
+}
+
+func (v *VhostResource) ProtectedFromDeletion(data TestData, protected bool) string {
+	return fmt.Sprintf(`
+	resource "%s" "%s" {
+		name = "%s"
+		protected_from_deletion = %t
+	}`, data.ResourceType, data.ResourceLabel, v.Name, protected)
+}
+
+func (v *VhostResource) PreventDestroyIfNotEmpty(data TestData, prevent bool) string {
+	return fmt.Sprintf(`
+	resource "%s" "%s" {
+		name = "%s"
+		prevent_destroy_if_not_empty = %t
+	}`, data.ResourceType, data.ResourceLabel, v.Name, prevent)
 }
 
 func (v *VhostResource) ErrorDefaultQueueTypeAttribute(data TestData) string {
@@ -141,7 +183,7 @@ func (v *VhostResource) DataSource(data TestData) string {
 
 func (v VhostResource) ExistsInRabbitMQ() error {
 
-	rmqc := TestAcc.Provider.Meta().(*rabbithole.Client)
+	rmqc := TestAcc.Provider.Meta().(*provider.Meta).Client
 	myVhost, err := rmqc.GetVhost(v.Name)
 	if err != nil {
 		return fmt.Errorf("error retrieving vhost '%s': %#v", v.Name, err)
@@ -169,6 +211,17 @@ func (v VhostResource) ExistsInRabbitMQ() error {
 		}
 	}
 
+	if len(v.Tags) > 0 {
+		if len(myVhost.Tags) != len(v.Tags) {
+			return fmt.Errorf("vhost tags number is not equal. Actual: '%d' Expected: %d", len(myVhost.Tags), len(v.Tags))
+		}
+		for i := 0; i < len(v.Tags); i++ {
+			if !slices.Contains(myVhost.Tags, v.Tags[i]) {
+				return fmt.Errorf("vhost tags '%s' is not contained. Actual: '%#v' Expected: %#v", v.Tags[i], myVhost.Tags, v.Tags)
+			}
+		}
+	}
+
 	myVhostLimits, err := rmqc.GetVhostLimits(v.Name)
 	if err != nil {
 		return fmt.Errorf("error retrieving vhost limit '%s': %#v", v.Name, err)
@@ -194,7 +247,7 @@ func (v VhostResource) ExistsInRabbitMQ() error {
 
 func (v VhostResource) CheckDestroy() resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := TestAcc.Provider.Meta().(*rabbithole.Client)
+		rmqc := TestAcc.Provider.Meta().(*provider.Meta).Client
 		vhost, err := rmqc.GetVhost(v.Name)
 		if err != nil && err.(rabbithole.ErrorResponse).StatusCode != 404 {
 			return fmt.Errorf("error retrieving vhost '%s': %#v", v.Name, err)
@@ -235,6 +288,44 @@ func (v VhostResource) DelDataSourceVhost(t *testing.T) {
 	}
 }
 
+// Declare a queue into the vhost and publish a message into it
+func (v VhostResource) SetQueueWithMessage(t *testing.T, queue string) {
+	rmqc := TestAcc.Client(t)
+
+	resp, err := rmqc.DeclareQueue(v.Name, queue, rabbithole.QueueSettings{Durable: true})
+	if err != nil || resp.StatusCode >= 400 {
+		t.Fatalf("Failed to declare the queue '%s': %#v", queue, err)
+	}
+
+	body := fmt.Sprintf(`{"properties":{},"routing_key":"%s","payload":"test","payload_encoding":"string"}`, queue)
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/exchanges/%s/amq.default/publish", rmqc.Endpoint, url.PathEscape(v.Name)), strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to build the publish request: %#v", err)
+	}
+	req.SetBasicAuth(rmqc.Username, rmqc.Password)
+	req.Header.Add("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil || res.StatusCode >= 400 {
+		t.Fatalf("Failed to publish a message into the queue '%s': %#v", queue, err)
+	}
+	res.Body.Close()
+
+	// Wait for the statistics of the queue to be refreshed
+	for i := 0; i < 30; i++ {
+		if q, err := rmqc.GetQueue(v.Name, queue); err == nil && q.Messages > 0 {
+			return
+		}
+		time.Sleep(time.Second)
+	}
+	t.Fatalf("The message is not counted into the queue '%s'", queue)
+}
+
+// 'protected_from_deletion' metadata is present into RabbitMQ 4.1 and later
+func (v VhostResource) HasDeletionProtectionFeature() bool {
+	return TestAcc.ValidFeature("4.1")
+}
+
 // 'Description' field can't be updated in 3.8. It was fixed in 3.9 and later
 func (v VhostResource) HasDescriptionUpdateFeature() bool {
 	return TestAcc.ValidFeature("3.9")
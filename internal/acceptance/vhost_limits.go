@@ -0,0 +1,101 @@
+package acceptance
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider"
+)
+
+type VhostLimitsResource struct {
+	Vhost          string
+	MaxConnections string
+	MaxQueues      string
+}
+
+func (v *VhostLimitsResource) vhost() string {
+	return fmt.Sprintf(`
+	resource "rabbitmq_vhost" "test" {
+		name = "%s"
+	}`, v.Vhost)
+}
+
+func (v *VhostLimitsResource) Create(data TestData) string {
+	limits := ""
+	if v.MaxConnections != "" {
+		limits += fmt.Sprintf("\n\t\tmax_connections = %s", v.MaxConnections)
+	}
+	if v.MaxQueues != "" {
+		limits += fmt.Sprintf("\n\t\tmax_queues = %s", v.MaxQueues)
+	}
+
+	return v.vhost() + fmt.Sprintf(`
+	resource "%s" "%s" {
+		vhost = rabbitmq_vhost.test.name%s
+	}`, data.ResourceType, data.ResourceLabel, limits)
+}
+
+func (v *VhostLimitsResource) Update(data TestData) string {
+	v.MaxConnections = data.RandomIntegerString()
+	v.MaxQueues = "-1"
+	return v.Create(data)
+}
+
+func (v *VhostLimitsResource) RemoveOne(data TestData) string {
+	v.MaxQueues = ""
+	return v.Create(data)
+}
+
+func (v *VhostLimitsResource) ConflictWithVhost(data TestData) string {
+	return fmt.Sprintf(`
+	resource "rabbitmq_vhost" "test" {
+		name = "%s"
+		max_connections = "%s"
+	}
+
+	resource "%s" "%s" {
+		vhost = rabbitmq_vhost.test.name
+		max_connections = %s
+	}`, v.Vhost, v.MaxConnections, data.ResourceType, data.ResourceLabel, v.MaxConnections)
+}
+
+func (v VhostLimitsResource) ExistsInRabbitMQ() error {
+	rmqc := TestAcc.Provider.Meta().(*provider.Meta).Client
+
+	myVhostLimits, err := rmqc.GetVhostLimits(v.Vhost)
+	if err != nil {
+		return fmt.Errorf("error retrieving vhost limit '%s': %#v", v.Vhost, err)
+	}
+
+	values := rabbithole.VhostLimitsValues{}
+	if len(myVhostLimits) > 0 {
+		values = myVhostLimits[0].Value
+	}
+
+	if err := checkLimit(values, "max-connections", v.MaxConnections); err != nil {
+		return fmt.Errorf("vhost %s", err)
+	}
+	if err := checkLimit(values, "max-queues", v.MaxQueues); err != nil {
+		return fmt.Errorf("vhost %s", err)
+	}
+
+	return nil
+}
+
+func (v VhostLimitsResource) CheckDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := TestAcc.Provider.Meta().(*provider.Meta).Client
+		myVhostLimits, err := rmqc.GetVhostLimits(v.Vhost)
+		if err != nil && err.(rabbithole.ErrorResponse).StatusCode != 404 {
+			return fmt.Errorf("error retrieving vhost limit '%s': %#v", v.Vhost, err)
+		}
+
+		if len(myVhostLimits) > 0 && len(myVhostLimits[0].Value) > 0 {
+			return fmt.Errorf("vhost limits still exist: %#v", myVhostLimits[0].Value)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,125 @@
+package acceptance
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider"
+)
+
+type VhostPermissionsResource struct {
+	Vhost          string
+	Users          []string
+	UnexpectedUser string
+}
+
+func (v *VhostPermissionsResource) Create(data TestData) string {
+	config := fmt.Sprintf(`
+	resource "rabbitmq_vhost" "test" {
+		name = "%s"
+	}`, v.Vhost)
+
+	permissions := ""
+	for i, user := range v.Users {
+		config += fmt.Sprintf(`
+
+	resource "rabbitmq_user" "user%d" {
+		name = "%s"
+		password = "%s"
+	}`, i, user, data.RandomString())
+
+		permissions += fmt.Sprintf(`
+		permissions {
+			user = rabbitmq_user.user%d.name
+			configure = ".*"
+			write = ".*"
+			read = ".*"
+		}`, i)
+	}
+
+	return config + fmt.Sprintf(`
+
+	resource "%s" "%s" {
+		vhost = rabbitmq_vhost.test.name
+		ignore_users = ["%s"]
+		%s
+	}`, data.ResourceType, data.ResourceLabel, os.Getenv("RABBITMQ_USERNAME"), permissions)
+}
+
+func (v *VhostPermissionsResource) Update(data TestData) string {
+	v.Users = v.Users[:1]
+	return v.Create(data)
+}
+
+// Grant permissions out of Terraform to an unexpected user and to the ignored user
+func (v *VhostPermissionsResource) SetUnexpectedPermissions(t *testing.T) {
+	rmqc := TestAcc.Client(t)
+	perms := rabbithole.Permissions{Configure: ".*", Write: ".*", Read: ".*"}
+
+	resp, err := rmqc.PutUser(v.UnexpectedUser, rabbithole.UserSettings{Password: v.UnexpectedUser})
+	if err != nil || resp.StatusCode >= 400 {
+		t.Fatalf("Failed to create the user '%s': %#v", v.UnexpectedUser, err)
+	}
+
+	for _, user := range []string{v.UnexpectedUser, os.Getenv("RABBITMQ_USERNAME")} {
+		resp, err = rmqc.UpdatePermissionsIn(v.Vhost, user, perms)
+		if err != nil || resp.StatusCode >= 400 {
+			t.Fatalf("Failed to grant the permissions of '%s': %#v", user, err)
+		}
+	}
+}
+
+func (v VhostPermissionsResource) ExistsInRabbitMQ() error {
+	rmqc := TestAcc.Provider.Meta().(*provider.Meta).Client
+
+	allPerms, err := rmqc.ListPermissions()
+	if err != nil {
+		return fmt.Errorf("error retrieving permissions: %#v", err)
+	}
+
+	var users []string
+	for _, p := range allPerms {
+		if p.Vhost == v.Vhost && p.User != os.Getenv("RABBITMQ_USERNAME") {
+			users = append(users, p.User)
+		}
+	}
+
+	if len(users) != len(v.Users) {
+		return fmt.Errorf("vhost permissions number is not equal. Actual: '%#v' Expected: %#v", users, v.Users)
+	}
+	for _, user := range v.Users {
+		if !slices.Contains(users, user) {
+			return fmt.Errorf("vhost permissions of '%s' are missing. Actual: '%#v'", user, users)
+		}
+	}
+
+	return nil
+}
+
+func (v VhostPermissionsResource) CheckDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := TestAcc.Provider.Meta().(*provider.Meta).Client
+
+		if v.UnexpectedUser != "" {
+			if _, err := rmqc.DeleteUser(v.UnexpectedUser); err != nil {
+				return fmt.Errorf("error deleting user '%s': %#v", v.UnexpectedUser, err)
+			}
+		}
+
+		vhost, err := rmqc.GetVhost(v.Vhost)
+		if err != nil && err.(rabbithole.ErrorResponse).StatusCode != 404 {
+			return fmt.Errorf("error retrieving vhost '%s': %#v", v.Vhost, err)
+		}
+
+		if vhost != nil {
+			return fmt.Errorf("vhost still exists: %s", v.Vhost)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+)
+
+// call an endpoint of the RabbitMQ HTTP API, the JSON response is decoded into 'rec' when it's not nil
+func apiRequest(m *Meta, method string, path string, body interface{}, rec interface{}) error {
+	var payload io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(b)
+	}
+
+	rmqc := m.Client
+	req, err := http.NewRequest(method, rmqc.Endpoint+"/api/"+path, payload)
+	if err != nil {
+		return err
+	}
+
+	req.Close = true
+	req.SetBasicAuth(rmqc.Username, rmqc.Password)
+	req.Header.Add("Content-Type", "application/json")
+
+	httpc := &http.Client{Transport: m.transport}
+
+	log.Printf("[DEBUG] RabbitMQ: %s request to '%s'", method, path)
+	res, err := httpc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("API responded with a 401 Unauthorized")
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		rme := rabbithole.ErrorResponse{}
+		if err = json.NewDecoder(res.Body).Decode(&rme); err != nil {
+			rme.Message = fmt.Sprintf("Error %d from RabbitMQ: %s", res.StatusCode, err)
+		}
+		rme.StatusCode = res.StatusCode
+		return rme
+	}
+
+	if rec != nil && res.StatusCode != http.StatusNoContent {
+		return json.NewDecoder(res.Body).Decode(rec)
+	}
+
+	return nil
+}
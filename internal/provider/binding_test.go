@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestCheckBindingSource(t *testing.T) {
+	var tests = []struct {
+		exchangeType    string
+		destinationType string
+		routingKey      string
+		selector        string
+		err             bool
+	}{
+		{"direct", "exchange", "key", "", false},
+		{"x-local-random", "queue", "", "", false},
+		{"x-local-random", "queue", "key", "", true},
+		{"x-local-random", "exchange", "", "", true},
+		{"x-jms-topic", "queue", "key", "{'=', {'ident', <<\"type\">>}, <<\"a\">>}", false},
+		{"topic", "queue", "key", "{'=', {'ident', <<\"type\">>}, <<\"a\">>}", true},
+	}
+
+	for _, test := range tests {
+		if err := checkBindingSource(test.exchangeType, test.destinationType, test.routingKey, test.selector); (err != nil) != test.err {
+			t.Errorf("checkBindingSource failed for %s to %s with %q: %v", test.exchangeType, test.destinationType, test.routingKey, err)
+		}
+	}
+}
+
+func TestSetBindingArguments(t *testing.T) {
+	selector := "{'=', {'ident', <<\"type\">>}, <<\"a\">>}"
+	serverArguments := map[string]interface{}{jmsTopicSelectorArgument: selector, "key": "value"}
+
+	var tests = []struct {
+		config            map[string]interface{}
+		expectedSelector  string
+		expectedArguments map[string]interface{}
+	}{
+		// the selector set with 'arguments' stays in the arguments
+		{
+			map[string]interface{}{"arguments": map[string]interface{}{jmsTopicSelectorArgument: selector, "key": "value"}},
+			"",
+			map[string]interface{}{jmsTopicSelectorArgument: selector, "key": "value"},
+		},
+		{
+			map[string]interface{}{"jms_topic_selector": selector, "arguments": map[string]interface{}{"key": "value"}},
+			selector,
+			map[string]interface{}{"key": "value"},
+		},
+	}
+
+	for _, test := range tests {
+		d := schema.TestResourceDataRaw(t, resourceBinding().Schema, test.config)
+		if err := setBindingArguments(d, serverArguments); err != nil {
+			t.Fatalf("setBindingArguments failed: %v", err)
+		}
+
+		if actual := d.Get("jms_topic_selector").(string); actual != test.expectedSelector {
+			t.Errorf("setBindingArguments failed for %#v: expected the selector %q, got %q", test.config, test.expectedSelector, actual)
+		}
+		if actual := d.Get("arguments").(map[string]interface{}); !reflect.DeepEqual(actual, test.expectedArguments) {
+			t.Errorf("setBindingArguments failed for %#v: expected the arguments %#v, got %#v", test.config, test.expectedArguments, actual)
+		}
+	}
+}
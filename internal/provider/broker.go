@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"log"
+
+	"golang.org/x/mod/semver"
+)
+
+// The information about the RabbitMQ broker the provider is connected to, retrieved when the provider is configured
+type brokerInfo struct {
+	version string
+	// the exchange types known by the broker, nil when they couldn't be detected
+	exchangeTypes []string
+	// the plugins enabled on the broker, nil when they couldn't be detected
+	plugins []string
+}
+
+func fetchBrokerInfo(m *Meta) *brokerInfo {
+	info := &brokerInfo{}
+
+	overview, err := m.Client.Overview()
+	if err != nil {
+		log.Printf("[WARN] RabbitMQ: unable to detect the version of the broker: %v", err)
+		return info
+	}
+
+	info.version = overview.RabbitMQVersion
+	log.Printf("[DEBUG] RabbitMQ: connected to RabbitMQ %s", info.version)
+
+	info.exchangeTypes = []string{}
+	for _, t := range overview.ExchangeTypes {
+		info.exchangeTypes = append(info.exchangeTypes, t.Name)
+	}
+	info.plugins = fetchEnabledPlugins(m)
+
+	return info
+}
+
+// the version of the broker is greater than or equal to 'version', false when the version is unknown
+func isVersionAtLeast(current string, version string) bool {
+	if current == "" {
+		return false
+	}
+	return semver.Compare("v"+current, "v"+version) >= 0
+}
@@ -0,0 +1,318 @@
+package provider
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The placeholder of the passwords which are redacted from the state
+const redactedPassword = "********"
+
+// uriParameter links an attribute of a connection block to a query parameter of the AMQP URIs
+type uriParameter struct {
+	attribute string
+	key       string
+	numeric   bool
+}
+
+// The query parameters of an AMQP URI which are set by the attributes of a connection block
+var connectionUriParameters = []uriParameter{
+	{attribute: "heartbeat", key: "heartbeat", numeric: true},
+	{attribute: "connection_timeout", key: "connection_timeout", numeric: true},
+	{attribute: "cacertfile", key: "cacertfile"},
+	{attribute: "certfile", key: "certfile"},
+	{attribute: "keyfile", key: "keyfile"},
+	{attribute: "verify", key: "verify"},
+	{attribute: "server_name_indication", key: "server_name_indication"},
+}
+
+// the schema of a structured connection, assembled into AMQP URIs by the provider
+func connectionSchema(description string, exactlyOneOf []string, forceNew bool) *schema.Schema {
+	return &schema.Schema{
+		Description:  description,
+		Type:         schema.TypeList,
+		Optional:     true,
+		ForceNew:     forceNew,
+		MaxItems:     1,
+		ExactlyOneOf: exactlyOneOf,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"hosts": {
+					Description: "The hosts of the broker, one URI is assembled by host and a link connects to one of them.",
+					Type:        schema.TypeList,
+					Required:    true,
+					ForceNew:    forceNew,
+					MinItems:    1,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"port": {
+					Description:  "The port of the broker. Defaults to the port of the protocol (`5672`, or `5671` with TLS).",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ForceNew:     forceNew,
+					ValidateFunc: validation.IsPortNumber,
+				},
+				"vhost": {
+					Description: "The vhost of the broker. Defaults to the default vhost of the broker.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					ForceNew:    forceNew,
+				},
+				"username": {
+					Description: "The user to connect with.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					ForceNew:    forceNew,
+				},
+				"password": {
+					Description: "The password of the user.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					ForceNew:    forceNew,
+					Sensitive:   true,
+				},
+				"tls": {
+					Description: "Connect with TLS (`amqps` scheme). Defaults to `false`.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					ForceNew:    forceNew,
+					Default:     false,
+				},
+				"cacertfile": {
+					Description: "The path, on the RabbitMQ nodes, of the CA certificates used to verify the broker.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					ForceNew:    forceNew,
+				},
+				"certfile": {
+					Description: "The path, on the RabbitMQ nodes, of the client certificate.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					ForceNew:    forceNew,
+				},
+				"keyfile": {
+					Description: "The path, on the RabbitMQ nodes, of the private key of the client certificate.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					ForceNew:    forceNew,
+				},
+				"verify": {
+					Description:  "The verification of the broker certificate: `verify_peer` or `verify_none`.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					ForceNew:     forceNew,
+					ValidateFunc: validation.StringInSlice([]string{"verify_peer", "verify_none"}, false),
+				},
+				"server_name_indication": {
+					Description: "The server name sent with TLS, `disable` to not send it.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					ForceNew:    forceNew,
+				},
+				"heartbeat": {
+					Description:  "The heartbeat timeout in seconds.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ForceNew:     forceNew,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"connection_timeout": {
+					Description:  "The connection timeout in milliseconds.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ForceNew:     forceNew,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+			},
+		},
+	}
+}
+
+// get the connection map of a connection block, nil when the block isn't set
+func getConnection(blocks interface{}) map[string]interface{} {
+	list, ok := blocks.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil
+	}
+	connection, _ := list[0].(map[string]interface{})
+	return connection
+}
+
+// assemble the URIs of a connection, one by host
+func expandConnectionUris(connection map[string]interface{}) []string {
+	scheme := "amqp"
+	if tls, _ := connection["tls"].(bool); tls {
+		scheme = "amqps"
+	}
+
+	var user *url.Userinfo
+	username, _ := connection["username"].(string)
+	password, _ := connection["password"].(string)
+	if password != "" {
+		user = url.UserPassword(username, password)
+	} else if username != "" {
+		user = url.User(username)
+	}
+
+	var path, rawPath string
+	if vhost, _ := connection["vhost"].(string); vhost != "" {
+		// a '/' in the vhost is percent-encoded, e.g. '%2F' for the default vhost
+		path, rawPath = "/"+vhost, "/"+url.PathEscape(vhost)
+	}
+
+	query := url.Values{}
+	for _, p := range connectionUriParameters {
+		switch v := connection[p.attribute].(type) {
+		case string:
+			if v != "" {
+				query.Set(p.key, v)
+			}
+		case int:
+			if v != 0 {
+				query.Set(p.key, strconv.Itoa(v))
+			}
+		}
+	}
+
+	port, _ := connection["port"].(int)
+	hosts, _ := connection["hosts"].([]interface{})
+	uris := make([]string, 0, len(hosts))
+
+	for _, h := range hosts {
+		host, _ := h.(string)
+		if port != 0 {
+			host = net.JoinHostPort(host, strconv.Itoa(port))
+		} else if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+
+		u := url.URL{Scheme: scheme, User: user, Host: host, Path: path, RawPath: rawPath, RawQuery: query.Encode()}
+		uris = append(uris, u.String())
+	}
+
+	return uris
+}
+
+// build a connection block from the URIs of the broker. The password of the current block is kept when it's
+// the one of the URIs, else it's redacted, so a password which isn't configured isn't read into the state.
+func flattenConnectionUris(uris []string, current map[string]interface{}) map[string]interface{} {
+	connection := map[string]interface{}{}
+	var hosts []string
+
+	for i, uri := range uris {
+		u, err := url.Parse(uri)
+		if err != nil {
+			continue
+		}
+
+		hosts = append(hosts, u.Hostname())
+		if i > 0 {
+			continue
+		}
+
+		connection["tls"] = u.Scheme == "amqps"
+		if p := u.Port(); p != "" {
+			connection["port"], _ = strconv.Atoi(p)
+		}
+		if vhost, err := url.PathUnescape(strings.TrimPrefix(u.EscapedPath(), "/")); err == nil && vhost != "" {
+			connection["vhost"] = vhost
+		}
+
+		if u.User != nil {
+			connection["username"] = u.User.Username()
+			if password, ok := u.User.Password(); ok {
+				if currentPassword, _ := current["password"].(string); currentPassword == password {
+					connection["password"] = password
+				} else {
+					connection["password"] = redactedPassword
+				}
+			}
+		}
+
+		query := u.Query()
+		for _, p := range connectionUriParameters {
+			v := query.Get(p.key)
+			if v == "" {
+				continue
+			}
+			if p.numeric {
+				connection[p.attribute], _ = strconv.Atoi(v)
+			} else {
+				connection[p.attribute] = v
+			}
+		}
+	}
+
+	connection["hosts"] = hosts
+
+	return connection
+}
+
+// replace the password of an URI by the placeholder, the URI is unchanged when it has no password
+func redactUri(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.User == nil {
+		return uri
+	}
+
+	password, ok := u.User.Password()
+	if !ok || password == redactedPassword {
+		return uri
+	}
+
+	// the placeholder is inserted as is, url.UserPassword would percent-encode it
+	user := u.User
+	u.User = nil
+	return strings.Replace(u.String(), "//", "//"+url.User(user.Username()).String()+":"+redactedPassword+"@", 1)
+}
+
+// the URIs stored into the state: an URI of the broker is kept when it's the configured one, its password is then
+// stored by a sensitive attribute, else its password is redacted so a password which isn't configured isn't read
+// into the state. A redacted password differs from the configuration, the URI is declared again on the next apply.
+func stateUris(uris []string, configured []string) []string {
+	state := make([]string, len(uris))
+	for i, uri := range uris {
+		if i < len(configured) && configured[i] == uri {
+			state[i] = uri
+		} else {
+			state[i] = redactUri(uri)
+		}
+	}
+	return state
+}
+
+// get the URIs of an attribute from the raw config, a string or a list of strings
+func getRawUris(raw cty.Value, attribute string) []string {
+	if !isRawAttributeSet(raw, attribute) {
+		return nil
+	}
+
+	v := raw.GetAttr(attribute)
+	if !v.IsWhollyKnown() {
+		return nil
+	}
+
+	if v.Type() == cty.String {
+		if v.AsString() == "" {
+			return nil
+		}
+		return []string{v.AsString()}
+	}
+
+	var uris []string
+	if v.CanIterateElements() {
+		for it := v.ElementIterator(); it.Next(); {
+			_, e := it.Element()
+			if !e.IsNull() && e.Type() == cty.String {
+				uris = append(uris, e.AsString())
+			}
+		}
+	}
+	return uris
+}
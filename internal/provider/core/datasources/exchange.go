@@ -1,8 +1,6 @@
 package datasources
 
 import (
-	"fmt"
-
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -76,7 +74,7 @@ func Exchange() map[string]*schema.Schema {
 						Computed:    true,
 					},
 					"type": {
-						Description: "The value type. Possible values are `string`, `numeric`, `boolean` and `list`.",
+						Description: "The value type. Possible values are `string`, `numeric`, `boolean`, `list` and `table`.",
 						Type:        schema.TypeString,
 						Computed:    true,
 					},
@@ -112,7 +110,7 @@ func ReadExchange(d *schema.ResourceData, rmqc infras.IRabbitMQInfra) diag.Diagn
 
 		var args []interface{}
 		for key, value := range exchange.Arguments {
-			args = append(args, map[string]interface{}{"key": key, "value": fmt.Sprintf("%v", value), "type": utils.GetArgumentType(value)})
+			args = append(args, map[string]interface{}{"key": key, "value": utils.FormatArgumentValue(value), "type": utils.GetArgumentType(value)})
 		}
 		d.Set("argument", args)
 	}
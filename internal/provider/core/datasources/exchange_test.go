@@ -38,7 +38,7 @@ func TestExchange_ReadExchange_Success(t *testing.T) {
 		Durable:    true,
 		AutoDelete: true,
 		Internal:   false,
-		Arguments:  map[string]interface{}{"alternate-exchange": "myAlternateExchange", "myStringKey": "myStringValue", "myNumericKey": 12345, "myBooleanKey": true},
+		Arguments:  map[string]interface{}{"alternate-exchange": "myAlternateExchange", "myStringKey": "myStringValue", "myNumericKey": 12345, "myBooleanKey": true, "myListKey": []interface{}{"a", float64(1)}},
 	}}}
 
 	// Test
@@ -54,7 +54,8 @@ func TestExchange_ReadExchange_Success(t *testing.T) {
 	assert.False(d.Get("internal").(bool))
 	assert.Equal("myAlternateExchange", d.Get("alternate_exchange"))
 	set := d.Get("argument").(*schema.Set)
-	assert.Len(set.List(), 3)
+	assert.Len(set.List(), 4)
+	assert.Contains(set.List(), map[string]interface{}{"key": "myListKey", "value": `["a",1]`, "type": "list"})
 }
 
 func getResourseDataExchange_Basic(t *testing.T) *schema.ResourceData {
@@ -78,16 +78,16 @@ func Exchange() map[string]*schema.Schema {
 						Required:    true,
 					},
 					"value": {
-						Description: "The argument value.",
+						Description: "The argument value. A `list` is a JSON array or comma-separated values, e.g. `a, 1, true`, whose plain decimal numbers and `true` or `false` literals are typed and the other values are strings. A `table` is a JSON object.",
 						Type:        schema.TypeString,
 						Required:    true,
 					},
 					"type": {
-						Description:  "The value type. Possible values are `string`, `numeric`, `boolean` and `list`. Defaults to `string`.",
+						Description:  "The value type. Possible values are `string`, `numeric`, `boolean`, `list` and `table`. Defaults to `string`.",
 						Type:         schema.TypeString,
 						Optional:     true,
 						Default:      "string",
-						ValidateFunc: validation.StringInSlice([]string{"string", "numeric", "boolean", "list"}, true),
+						ValidateFunc: validation.StringInSlice([]string{"string", "numeric", "boolean", "list", "table"}, true),
 					},
 				},
 			},
@@ -134,6 +134,9 @@ func ReadExchange(d *schema.ResourceData, rmqc infras.IRabbitMQInfra) error {
 		return utils.CheckDeletedResource(d, err)
 	}
 
+	// the state is empty on import
+	imported := d.Get("name").(string) == ""
+
 	d.Set("name", exchange.Name)
 	d.Set("vhost", exchange.Vhost)
 	d.Set("type", exchange.Type)
@@ -141,20 +144,46 @@ func ReadExchange(d *schema.ResourceData, rmqc infras.IRabbitMQInfra) error {
 	d.Set("auto_delete", exchange.AutoDelete)
 	d.Set("internal", exchange.Internal)
 
-	if len(exchange.Arguments) > 0 {
-		if val := exchange.Arguments["alternate-exchange"]; val != nil {
-			d.Set("alternate_exchange", val)
-			delete(exchange.Arguments, "alternate-exchange")
-		}
+	if val := exchange.Arguments["alternate-exchange"]; val != nil {
+		d.Set("alternate_exchange", val)
+		delete(exchange.Arguments, "alternate-exchange")
+	}
+	d.Set("argument", readExchangeArguments(d, exchange.Arguments, imported))
+
+	return nil
+}
 
-		var args []interface{}
-		for key, value := range exchange.Arguments {
-			args = append(args, map[string]interface{}{"key": key, "value": fmt.Sprintf("%v", value), "type": utils.GetArgumentType(value)})
+// build the argument set from the arguments of the exchange. A configured argument is kept as is when the broker
+// returns the same value, so its type and format don't drift. The arguments which aren't configured, e.g. the
+// defaults added by the broker or a plugin, are ignored, but all of them are read on import.
+func readExchangeArguments(d *schema.ResourceData, arguments map[string]interface{}, imported bool) []interface{} {
+	configured := make(map[string]map[string]interface{})
+	for _, v := range d.Get("argument").(*schema.Set).List() {
+		arg := v.(map[string]interface{})
+		configured[arg["key"].(string)] = arg
+	}
+
+	args := []interface{}{}
+	for key, value := range arguments {
+		arg, ok := configured[key]
+		if !ok && !imported {
+			continue
 		}
-		d.Set("argument", args)
+		if ok {
+			if configuredValue, err := utils.GetArgumentValue(arg); err == nil && utils.EqualArgumentValues(configuredValue, value) {
+				args = append(args, arg)
+				continue
+			}
+			// the exchanges declared before the list arguments were parsed hold the raw string of the list
+			if raw, isString := value.(string); isString && raw == arg["value"].(string) {
+				args = append(args, arg)
+				continue
+			}
+		}
+		args = append(args, map[string]interface{}{"key": key, "value": utils.FormatArgumentValue(value), "type": utils.GetArgumentType(value)})
 	}
 
-	return nil
+	return args
 }
 
 func DeleteExchange(d *schema.ResourceData, rmqc infras.IRabbitMQInfra) error {
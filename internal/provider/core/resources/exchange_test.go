@@ -88,6 +88,32 @@ func TestExchange_CreateExchange_Success(t *testing.T) {
 	assert.Equal("myName@myVhost", d.Id())
 }
 
+func TestExchange_CreateExchange_ListArgument(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// Mock RabbitMQ Infrastructure
+	mock := &mock_test.RabbitMQInfraMock{
+		Read:   mock_test.RabbitMQInfraMock_Exchange{Err: errors.New("exchange not found!"), Rec: nil},
+		Create: mock_test.RabbitMQInfraMock_Response{Err: nil, Res: &http.Response{StatusCode: 200}},
+	}
+
+	// Test
+	d := schema.TestResourceDataRaw(t, resources.Exchange(), map[string]interface{}{
+		"name":  "myName",
+		"vhost": "myVhost",
+		"argument": []interface{}{
+			map[string]interface{}{"key": "myKey", "value": `["myString", 12345`, "type": "list"},
+		},
+	})
+	err := resources.CreateExchange(d, mock)
+
+	// Assert the expected behavior
+	require.Error(err)
+	require.ErrorContains(err, "failed to parse list")
+	assert.Empty(d.Id())
+}
+
 func TestExchange_ReadExchange_FailedId(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -153,6 +179,49 @@ func TestExchange_ReadExchange_Success(t *testing.T) {
 	assert.Len(set.List(), 3)
 }
 
+func TestExchange_ReadExchange_Arguments(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// Mock RabbitMQ Infrastructure
+	mock := &mock_test.RabbitMQInfraMock{Read: mock_test.RabbitMQInfraMock_Exchange{Err: nil, Rec: &rabbithole.DetailedExchangeInfo{
+		Name:  "myName",
+		Vhost: "myVhost",
+		Type:  "direct",
+		Arguments: map[string]interface{}{
+			"myNumericKey": float64(10),
+			"myListKey":    []interface{}{"a", float64(1)},
+			"myTableKey":   map[string]interface{}{"b": true},
+			"myDefaultKey": "myDefaultValue",
+			"myRawListKey": "a,b",
+		},
+	}}}
+
+	// Test
+	d := schema.TestResourceDataRaw(t, resources.Exchange(), map[string]interface{}{
+		"name":  "myName",
+		"vhost": "myVhost",
+		"argument": []interface{}{
+			map[string]interface{}{"key": "myNumericKey", "value": "10.0", "type": "numeric"},
+			map[string]interface{}{"key": "myListKey", "value": "myChangedValue", "type": "string"},
+			map[string]interface{}{"key": "myTableKey", "value": `{"b": true}`, "type": "table"},
+			map[string]interface{}{"key": "myRawListKey", "value": "a,b", "type": "list"},
+		},
+	})
+	d.SetId("myName@myVhost")
+	err := resources.ReadExchange(d, mock)
+
+	// Assert the expected behavior
+	require.NoError(err)
+	set := d.Get("argument").(*schema.Set)
+	assert.ElementsMatch([]interface{}{
+		map[string]interface{}{"key": "myNumericKey", "value": "10.0", "type": "numeric"},
+		map[string]interface{}{"key": "myListKey", "value": `["a",1]`, "type": "list"},
+		map[string]interface{}{"key": "myTableKey", "value": `{"b": true}`, "type": "table"},
+		map[string]interface{}{"key": "myRawListKey", "value": "a,b", "type": "list"},
+	}, set.List())
+}
+
 func TestExchange_DeleteExchange_FailedId(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcesEffectivePolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to resolve which _policy_ and _operator policy_ apply to a queue or an exchange, and the resulting definition. The resolution is done by the provider from the policies of the vhost, the queue or the exchange doesn't need to exist.\n-> **Note:** The patterns using a syntax which is not supported by the provider (e.g. lookarounds) are ignored with a warning.",
+		ReadContext: dataSourcesReadEffectivePolicy,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vhost": {
+				Description: "The vhost of the queue or the exchange.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"name": {
+				Description: "The name of the queue or the exchange.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"kind": {
+				Description:  "The kind of object. Can either be `queue` or `exchange`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"queue", "exchange"}, false),
+			},
+			"queue_type": {
+				Description:  "The type of the queue. Can be `classic`, `quorum` or `stream`. Defaults to `classic`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "classic",
+				ValidateFunc: validation.StringInSlice([]string{"classic", "quorum", "stream"}, false),
+			},
+			"policy": {
+				Description: "The name of the policy applied to the object, empty when no policy matches.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"operator_policy": {
+				Description: "The name of the operator policy applied to the queue, empty when no operator policy matches.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"definition_json": {
+				Description: "The effective definition as a JSON object: the definition of the policy merged with the one of the operator policy.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"definition": {
+				Description: "The effective definition as key/value pairs, the values which are not strings are JSON encoded.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourcesReadEffectivePolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*Meta).Client
+
+	vhost := d.Get("vhost").(string)
+	name := d.Get("name").(string)
+	kind := d.Get("kind").(string)
+	queueType := d.Get("queue_type").(string)
+
+	policies, err := rmqc.ListPoliciesIn(vhost)
+	if err != nil {
+		return diag.Errorf("error listing the policies of vhost '%s': %#v", vhost, err)
+	}
+
+	var operatorPolicies []rabbithole.OperatorPolicy
+	if kind == "queue" {
+		operatorPolicies, err = rmqc.ListOperatorPoliciesIn(vhost)
+		if err != nil {
+			return diag.Errorf("error listing the operator policies of vhost '%s': %#v", vhost, err)
+		}
+	}
+
+	policyName, operatorPolicyName, definition, diags := resolveEffectivePolicy(policyCandidates(policies), operatorPolicyCandidates(operatorPolicies), name, kind, queueType)
+
+	definitionJson, err := json.Marshal(definition)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	definitionMap := make(map[string]string)
+	for key, value := range definition {
+		switch v := value.(type) {
+		case string:
+			definitionMap[key] = v
+		case float64:
+			definitionMap[key] = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			b, _ := json.Marshal(v)
+			definitionMap[key] = string(b)
+		}
+	}
+
+	d.Set("policy", policyName)
+	d.Set("operator_policy", operatorPolicyName)
+	d.Set("definition_json", string(definitionJson))
+	d.Set("definition", definitionMap)
+
+	d.SetId(fmt.Sprintf("%s/%s@%s", kind, name, vhost))
+
+	return diags
+}
+
+// resolve the policy and the operator policy applied to an object, and the resulting definition
+func resolveEffectivePolicy(policies []policyCandidate, operatorPolicies []policyCandidate, name string, kind string, queueType string) (policy string, operatorPolicy string, definition map[string]interface{}, diags diag.Diagnostics) {
+	definition = make(map[string]interface{})
+
+	matches, diags := matchingPolicies(policies, name, kind, queueType)
+	if len(matches) > 0 {
+		policy = matches[0].name
+		definition = matches[0].definition
+	}
+
+	if kind != "queue" {
+		return policy, operatorPolicy, definition, diags
+	}
+
+	opMatches, opDiags := matchingPolicies(operatorPolicies, name, kind, queueType)
+	diags = append(diags, opDiags...)
+	if len(opMatches) > 0 {
+		operatorPolicy = opMatches[0].name
+		definition = mergeOperatorPolicyDefinition(definition, opMatches[0].definition)
+	}
+
+	return policy, operatorPolicy, definition, diags
+}
@@ -0,0 +1,90 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/acceptance"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccEffectivePolicy_DataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAcc.PreCheck(t) },
+		Providers: acceptance.TestAcc.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEffectivePolicyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rabbitmq_effective_policy.queue", "policy", "orders"),
+					resource.TestCheckResourceAttr("data.rabbitmq_effective_policy.queue", "operator_policy", "clamp"),
+					resource.TestCheckResourceAttr("data.rabbitmq_effective_policy.queue", "definition.message-ttl", "30000"),
+					resource.TestCheckResourceAttr("data.rabbitmq_effective_policy.queue", "definition.max-length", "100"),
+					resource.TestCheckResourceAttr("data.rabbitmq_effective_policy.exchange", "policy", "all"),
+					resource.TestCheckResourceAttr("data.rabbitmq_effective_policy.exchange", "operator_policy", ""),
+					resource.TestCheckResourceAttr("data.rabbitmq_effective_policy.exchange", "definition_json", `{"alternate-exchange":"ae"}`),
+				),
+			},
+		},
+	})
+}
+
+const testAccEffectivePolicyConfig = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_policy" "all" {
+    name = "all"
+    vhost = rabbitmq_vhost.test.name
+    policy {
+        pattern = ".*"
+        priority = 0
+        apply_to = "exchanges"
+        definition = {
+            alternate-exchange = "ae"
+        }
+    }
+}
+
+resource "rabbitmq_policy" "orders" {
+    name = "orders"
+    vhost = rabbitmq_vhost.test.name
+    policy {
+        pattern = "^orders\\."
+        priority = 10
+        apply_to = "queues"
+        message_ttl = 60000
+        max_length = 100
+    }
+}
+
+resource "rabbitmq_operator_policy" "clamp" {
+    name = "clamp"
+    vhost = rabbitmq_vhost.test.name
+    policy {
+        pattern = ".*"
+        priority = 0
+        apply_to = "queues"
+        definition = {
+            message-ttl = 30000
+            max-length = 1000
+        }
+    }
+}
+
+data "rabbitmq_effective_policy" "queue" {
+    vhost = rabbitmq_vhost.test.name
+    name = "orders.eu"
+    kind = "queue"
+
+    depends_on = [rabbitmq_policy.all, rabbitmq_policy.orders, rabbitmq_operator_policy.clamp]
+}
+
+data "rabbitmq_effective_policy" "exchange" {
+    vhost = rabbitmq_vhost.test.name
+    name = "orders"
+    kind = "exchange"
+
+    depends_on = [rabbitmq_policy.all, rabbitmq_policy.orders, rabbitmq_operator_policy.clamp]
+}`
@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
-
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -82,7 +80,7 @@ func dataSourcesExchange() *schema.Resource {
 func dataSourcesReadExchange(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name := d.Get("name").(string)
 	vhost := d.Get("vhost").(string)
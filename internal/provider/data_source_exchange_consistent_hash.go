@@ -5,7 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/core/datasources"
 )
 
@@ -18,5 +17,5 @@ func datasourceExchangeConsistentHash() *schema.Resource {
 }
 
 func datasourceReadExchangeConsistentHash(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return datasources.ReadExchange(d, meta.(*rabbithole.Client))
+	return datasources.ReadExchange(d, meta.(*Meta).Client)
 }
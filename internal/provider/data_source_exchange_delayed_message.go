@@ -5,7 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/core/datasources"
 )
 
@@ -26,7 +25,7 @@ func datasourceExchangeDelayedMessage() *schema.Resource {
 }
 
 func datasourceReadExchangeDelayedMessage(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	diag := datasources.ReadExchange(d, meta.(*rabbithole.Client))
+	diag := datasources.ReadExchange(d, meta.(*Meta).Client)
 
 	// Add specific argument
 	args := d.Get("argument").(*schema.Set)
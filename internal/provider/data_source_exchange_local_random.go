@@ -0,0 +1,21 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/core/datasources"
+)
+
+func datasourceExchangeLocalRandom() *schema.Resource {
+	return &schema.Resource{
+		Description: "Exchange --- Use this data source to access information about an existing _exchange_ of type 'x-local-random'.",
+		ReadContext: datasourceReadExchangeLocalRandom,
+		Schema:      datasources.Exchange(),
+	}
+}
+
+func datasourceReadExchangeLocalRandom(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return datasources.ReadExchange(d, meta.(*Meta).Client)
+}
@@ -0,0 +1,21 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/core/datasources"
+)
+
+func datasourceExchangeModulusHash() *schema.Resource {
+	return &schema.Resource{
+		Description: "Exchange --- Use this data source to access information about an existing _exchange_ of type 'x-modulus-hash'.",
+		ReadContext: datasourceReadExchangeModulusHash,
+		Schema:      datasources.Exchange(),
+	}
+}
+
+func datasourceReadExchangeModulusHash(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return datasources.ReadExchange(d, meta.(*Meta).Client)
+}
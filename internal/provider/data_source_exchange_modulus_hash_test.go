@@ -0,0 +1,70 @@
+package provider_test
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"testing"
+
+	acceptance_test "github.com/rfd59/terraform-provider-rabbitmq/test/acceptance"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccExchangeModulusHash_DataSource(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests skipped unless env 'TF_ACC' set")
+	}
+
+	data := acceptance_test.BuildTestData("rabbitmq_exchange_modulus_hash", "test")
+	r := acceptance_test.ExchangeModulusHashResource{
+		ExchangeResource: acceptance_test.ExchangeResource{
+			Name:              data.RandomString(),
+			Vhost:             "/",
+			Type:              "x-modulus-hash",
+			Durable:           true,
+			AlternateExchange: data.RandomString(),
+		}}
+
+	// Create an exchange to test the datasource
+	r.SetDataSourceExchange(t)
+	defer r.DelDataSourceExchange(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance_test.TestAcc.PreCheck(t) },
+		Providers: acceptance_test.TestAcc.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: r.DataSource(data),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance_test.That("data."+data.ResourceName).Exists(),
+					acceptance_test.That("data."+data.ResourceName).Key("id").HasValue(r.Name+"@"+r.Vhost),
+					acceptance_test.That("data."+data.ResourceName).Key("name").HasValue(r.Name),
+					acceptance_test.That("data."+data.ResourceName).Key("type").HasValue(r.Type),
+					acceptance_test.That("data."+data.ResourceName).Key("durable").HasValue(strconv.FormatBool(r.Durable)),
+					acceptance_test.That("data."+data.ResourceName).Key("alternate_exchange").HasValue(r.AlternateExchange),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExchangeModulusHash_DataSourceNotExist(t *testing.T) {
+	data := acceptance_test.BuildTestData("rabbitmq_exchange_modulus_hash", "test")
+	r := acceptance_test.ExchangeModulusHashResource{
+		ExchangeResource: acceptance_test.ExchangeResource{
+			Name: data.RandomString(),
+		},
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance_test.TestAcc.PreCheck(t) },
+		Providers: acceptance_test.TestAcc.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      r.DataSource(data),
+				ExpectError: regexp.MustCompile("is not found"),
+			},
+		},
+	})
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/core/datasources"
+)
+
+func datasourceExchangeRecentHistory() *schema.Resource {
+	// Load and customize the resource schema
+	mySchema := datasources.Exchange()
+	mySchema["history_length"] = &schema.Schema{
+		Description: "The number of the latest messages kept by the exchange, `0` when it's the default of the broker.",
+		Type:        schema.TypeInt,
+		Computed:    true,
+	}
+
+	return &schema.Resource{
+		Description: "Exchange --- Use this data source to access information about an existing _exchange_ of type 'x-recent-history'.",
+		ReadContext: datasourceReadExchangeRecentHistory,
+		Schema:      mySchema,
+	}
+}
+
+func datasourceReadExchangeRecentHistory(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diag := datasources.ReadExchange(d, meta.(*Meta).Client)
+
+	// Add specific argument
+	d.Set("history_length", popHistoryLength(d))
+
+	return diag
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcesFederationLinks() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to access the status of the _federation links_ of a vhost.",
+		ReadContext: dataSourcesReadFederationLinks,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vhost": {
+				Description: "The vhost of the federation links.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"upstream": {
+				Description: "The name of a federation upstream, to only get its links.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"links": {
+				Description: "The federation links.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The identifier of the link.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"node": {
+							Description: "The node running the link.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"upstream": {
+							Description: "The name of the federation upstream.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "The type of the link: `exchange` or `queue`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"exchange": {
+							Description: "The federated exchange, for an `exchange` link.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"queue": {
+							Description: "The federated queue, for a `queue` link.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"upstream_exchange": {
+							Description: "The upstream exchange, for an `exchange` link.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"upstream_queue": {
+							Description: "The upstream queue, for a `queue` link.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"status": {
+							Description: "The status of the link: `starting`, `running` or `error`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"error": {
+							Description: "The error of the link, when its status is `error`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"timestamp": {
+							Description: "The time of the last status change of the link.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcesReadFederationLinks(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*Meta).Client
+
+	// the links are reported by the management plugin of the federation
+	if err := checkResourcePlugins(meta, pluginFederationManagement); err != nil {
+		return diag.FromErr(err)
+	}
+
+	vhost := d.Get("vhost").(string)
+	upstream := d.Get("upstream").(string)
+
+	links, err := rmqc.ListFederationLinksIn(vhost)
+	if err != nil {
+		return diag.Errorf("error listing the federation links of vhost '%s': %#v", vhost, err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(links))
+	for _, l := range parseFederationLinks(links) {
+		if upstream != "" && l.upstream != upstream {
+			continue
+		}
+
+		result = append(result, map[string]interface{}{
+			"id":                l.id,
+			"node":              l.node,
+			"upstream":          l.upstream,
+			"type":              l.linkType,
+			"exchange":          l.exchange,
+			"queue":             l.queue,
+			"upstream_exchange": l.upstreamExchange,
+			"upstream_queue":    l.upstreamQueue,
+			"status":            l.status,
+			"error":             l.error,
+			"timestamp":         l.timestamp,
+		})
+	}
+
+	if err := d.Set("links", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if upstream != "" {
+		d.SetId(fmt.Sprintf("%s@%s", upstream, vhost))
+	} else {
+		d.SetId(vhost)
+	}
+
+	return diags
+}
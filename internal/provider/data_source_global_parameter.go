@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcesGlobalParameter() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to access information about an existing global runtime _parameter_ (e.g. `cluster_name`).",
+		ReadContext: dataSourcesReadGlobalParameter,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Description: "The name of the global parameter.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"value": {
+				Description: "The value of the global parameter as a JSON document.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourcesReadGlobalParameter(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*Meta).Client
+
+	name := d.Get("name").(string)
+
+	parameter, err := rmqc.GetGlobalParameter(name)
+	if err != nil {
+		return diag.Errorf("global parameter '%s' is not found: %#v", name, err)
+	}
+
+	value, err := parameterValueToJson(parameter.Value)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("value", value)
+
+	d.SetId(name)
+
+	return diags
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcesParameter() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to access information about an existing vhost-scoped runtime _parameter_.",
+		ReadContext: dataSourcesReadParameter,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"component": {
+				Description: "The component of the parameter (e.g. `federation-upstream-set`).",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"name": {
+				Description: "The name of the parameter.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"vhost": {
+				Description: "The vhost of the parameter. Defaults to `/`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "/",
+			},
+			"value": {
+				Description: "The value of the parameter as a JSON document.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourcesReadParameter(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rmqc := meta.(*Meta).Client
+
+	component := d.Get("component").(string)
+	name := d.Get("name").(string)
+	vhost := d.Get("vhost").(string)
+
+	parameter, err := rmqc.GetRuntimeParameter(component, vhost, name)
+	if err != nil {
+		return diag.Errorf("parameter '%s' of component '%s' in vhost '%s' is not found: %#v", name, component, vhost, err)
+	}
+
+	value, err := parameterValueToJson(parameter.Value)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("value", value)
+
+	d.SetId(fmt.Sprintf("%s/%s@%s", component, name, vhost))
+
+	return diags
+}
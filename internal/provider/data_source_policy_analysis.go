@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcesPolicyAnalysis() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to analyze the _policies_ and the _operator policies_ of a vhost. The conflicts between the policies are reported in the `warnings` attribute and as Terraform warnings:\n" +
+			"* `overlap`: two policies have the same priority and their patterns can match the same object, RabbitMQ doesn't define which one is applied.\n" +
+			"* `shadowed`: a policy is never applied because a policy with a higher priority matches all its objects.\n" +
+			"* `clamped`: an operator policy lowers a value of a policy for the queues matched by both.\n" +
+			"-> **Note:** The patterns using a syntax which is not supported by the provider (e.g. lookarounds) are ignored with a warning. The word boundaries (`\\b`) are considered as always satisfied.",
+		ReadContext: dataSourcesReadPolicyAnalysis,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vhost": {
+				Description: "The vhost of the policies.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"warnings": {
+				Description: "The conflicts between the policies of the vhost.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Description: "The type of conflict: `overlap`, `shadowed` or `clamped`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"operator": {
+							Description: "The conflict is between two operator policies.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"policy": {
+							Description: "The name of the policy which is overlapped, shadowed or clamped.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"other_policy": {
+							Description: "The name of the policy in conflict. It's an operator policy for the `clamped` type.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"key": {
+							Description: "The key of the definition which is clamped, empty for the other types.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"message": {
+							Description: "The description of the conflict.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcesReadPolicyAnalysis(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*Meta).Client
+
+	vhost := d.Get("vhost").(string)
+
+	policies, err := rmqc.ListPoliciesIn(vhost)
+	if err != nil {
+		return diag.Errorf("error listing the policies of vhost '%s': %#v", vhost, err)
+	}
+
+	operatorPolicies, err := rmqc.ListOperatorPoliciesIn(vhost)
+	if err != nil {
+		return diag.Errorf("error listing the operator policies of vhost '%s': %#v", vhost, err)
+	}
+
+	findings, diags := analyzePolicies(policyCandidates(policies), operatorPolicyCandidates(operatorPolicies))
+
+	warnings := make([]map[string]interface{}, len(findings))
+	for i, f := range findings {
+		warnings[i] = map[string]interface{}{
+			"type":         f.findingType,
+			"operator":     f.operator,
+			"policy":       f.policy,
+			"other_policy": f.otherPolicy,
+			"key":          f.key,
+			"message":      f.message,
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  policyFindingSummaries[f.findingType],
+			Detail:   f.message,
+		})
+	}
+
+	if err := d.Set("warnings", warnings); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	d.SetId(vhost)
+
+	return diags
+}
+
+var policyFindingSummaries = map[string]string{
+	policyFindingOverlap:  "Policies with the same priority",
+	policyFindingShadowed: "Policy never applied",
+	policyFindingClamped:  "Policy value clamped by an operator policy",
+}
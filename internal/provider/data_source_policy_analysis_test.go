@@ -0,0 +1,103 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/acceptance"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPolicyAnalysis_DataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAcc.PreCheck(t) },
+		Providers: acceptance.TestAcc.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyAnalysisConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rabbitmq_policy_analysis.test", "warnings.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.rabbitmq_policy_analysis.test", "warnings.*", map[string]string{
+						"type":         "overlap",
+						"policy":       "orders",
+						"other_policy": "orders-eu",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.rabbitmq_policy_analysis.test", "warnings.*", map[string]string{
+						"type":         "shadowed",
+						"policy":       "orders-old",
+						"other_policy": "orders",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.rabbitmq_policy_analysis.test", "warnings.*", map[string]string{
+						"type":         "clamped",
+						"policy":       "orders",
+						"other_policy": "clamp",
+						"key":          "max-length",
+					}),
+				),
+			},
+		},
+	})
+}
+
+const testAccPolicyAnalysisConfig = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_policy" "orders" {
+    name = "orders"
+    vhost = rabbitmq_vhost.test.name
+    policy {
+        pattern = "^orders\\."
+        priority = 10
+        apply_to = "queues"
+        max_length = 1000
+    }
+}
+
+resource "rabbitmq_policy" "orders_eu" {
+    name = "orders-eu"
+    vhost = rabbitmq_vhost.test.name
+    policy {
+        pattern = "\\.eu$"
+        priority = 10
+        apply_to = "queues"
+        message_ttl = 60000
+    }
+}
+
+resource "rabbitmq_policy" "orders_old" {
+    name = "orders-old"
+    vhost = rabbitmq_vhost.test.name
+    policy {
+        pattern = "^orders\\.old\\."
+        priority = 0
+        apply_to = "quorum_queues"
+        delivery_limit = 5
+    }
+}
+
+resource "rabbitmq_operator_policy" "clamp" {
+    name = "clamp"
+    vhost = rabbitmq_vhost.test.name
+    policy {
+        pattern = ".*"
+        priority = 0
+        apply_to = "queues"
+        definition = {
+            max-length = 100
+        }
+    }
+}
+
+data "rabbitmq_policy_analysis" "test" {
+    vhost = rabbitmq_vhost.test.name
+
+    depends_on = [
+        rabbitmq_policy.orders,
+        rabbitmq_policy.orders_eu,
+        rabbitmq_policy.orders_old,
+        rabbitmq_operator_policy.clamp,
+    ]
+}
+`
@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"time"
 
-	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
-
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -48,7 +46,7 @@ func dataSourcesQueue() *schema.Resource {
 func dataSourcesReadQueue(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name := d.Get("name").(string)
 	vhost := d.Get("vhost").(string)
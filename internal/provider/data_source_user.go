@@ -6,7 +6,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 )
 
 func dataSourcesUser() *schema.Resource {
@@ -45,7 +44,7 @@ func dataSourcesUser() *schema.Resource {
 
 func dsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	name := d.Get("name").(string)
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	user, err := rmqc.GetUser(name)
 	if err != nil {
@@ -3,8 +3,6 @@ package provider
 import (
 	"context"
 
-	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
-
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -30,7 +28,7 @@ func dataSourcesVhost() *schema.Resource {
 func dataSourcesReadVhost(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name := d.Get("name").(string)
 
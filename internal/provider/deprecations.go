@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// deprecatedFeature describes a feature deprecated or removed by a version of RabbitMQ.
+// The name is the one of the deprecated feature in RabbitMQ, when it has one.
+type deprecatedFeature struct {
+	name         string
+	summary      string
+	deprecatedIn string
+	removedIn    string
+	alternative  string
+	link         string
+}
+
+var (
+	featureClassicQueueMirroring = deprecatedFeature{
+		name:         "classic_queue_mirroring",
+		summary:      "Classic queue mirroring",
+		deprecatedIn: "3.9",
+		removedIn:    "4.0",
+		alternative:  "Use quorum queues or streams for replicated queues and remove the `ha-*` keys from the policies.",
+		link:         "https://www.rabbitmq.com/docs/migrate-mcq-to-qq",
+	}
+	featureLazyQueueMode = deprecatedFeature{
+		summary:      "The lazy mode of the classic queues",
+		deprecatedIn: "3.12",
+		alternative:  "Since RabbitMQ 3.12 the classic queues behave like lazy queues, the `queue-mode` key and the `x-queue-mode` argument are ignored and can be removed.",
+		link:         "https://www.rabbitmq.com/docs/lazy-queues",
+	}
+	featureTransientNonExclusiveQueues = deprecatedFeature{
+		name:         "transient_nonexcl_queues",
+		summary:      "Transient non-exclusive queues",
+		deprecatedIn: "3.13",
+		alternative:  "Declare the queue as durable or use a quorum queue, a queue with the `x-expires` argument can replace a transient queue.",
+		link:         "https://www.rabbitmq.com/docs/queues#temporary-queues",
+	}
+	featureGlobalQos = deprecatedFeature{
+		name:         "global_qos",
+		summary:      "Global QoS",
+		deprecatedIn: "3.13",
+		alternative:  "Use a per-consumer prefetch instead of a per-channel (global) prefetch in the client applications.",
+		link:         "https://www.rabbitmq.com/docs/consumer-prefetch",
+	}
+
+	deprecatedFeatures = []deprecatedFeature{
+		featureClassicQueueMirroring,
+		featureLazyQueueMode,
+		featureTransientNonExclusiveQueues,
+		featureGlobalQos,
+	}
+)
+
+// get the diagnostic about the use of the feature by the broker version: an error when the feature is removed,
+// a warning when it's deprecated and nil when it's supported or when the version is unknown
+func (f deprecatedFeature) diagnostic(version string, usage string) *diag.Diagnostic {
+	switch {
+	case f.removedIn != "" && isVersionAtLeast(version, f.removedIn):
+		return &diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s is not supported by RabbitMQ %s", f.summary, version),
+			Detail:   fmt.Sprintf("%s %s was removed in RabbitMQ %s. %s See %s", usage, f.summary, f.removedIn, f.alternative, f.link),
+		}
+	case isVersionAtLeast(version, f.deprecatedIn):
+		return &diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s is deprecated", f.summary),
+			Detail:   fmt.Sprintf("%s %s is deprecated since RabbitMQ %s. %s See %s", usage, f.summary, f.deprecatedIn, f.alternative, f.link),
+		}
+	}
+	return nil
+}
+
+// get the diagnostics about the deprecated features used by a policy definition
+func policyDeprecations(definition map[string]interface{}, version string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var haKeys []string
+	for key := range definition {
+		if strings.HasPrefix(key, "ha-") {
+			haKeys = append(haKeys, key)
+		}
+	}
+	sort.Strings(haKeys)
+
+	if len(haKeys) > 0 {
+		usage := fmt.Sprintf("The policy uses the keys '%s' of", strings.Join(haKeys, "', '"))
+		if d := featureClassicQueueMirroring.diagnostic(version, usage); d != nil {
+			d.AttributePath = cty.GetAttrPath("policy")
+			diags = append(diags, *d)
+		}
+	}
+
+	if definition["queue-mode"] == "lazy" {
+		if d := featureLazyQueueMode.diagnostic(version, "The policy sets the key 'queue-mode' to 'lazy', it uses"); d != nil {
+			d.AttributePath = cty.GetAttrPath("policy")
+			diags = append(diags, *d)
+		}
+	}
+
+	return diags
+}
+
+// get the diagnostics about the deprecated features used by the settings of a queue
+func queueDeprecations(durable bool, arguments map[string]interface{}, version string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	queueType, _ := arguments["x-queue-type"].(string)
+	if !durable && (queueType == "" || queueType == "classic") {
+		if d := featureTransientNonExclusiveQueues.diagnostic(version, "The queue is not durable, it's one of the"); d != nil {
+			d.AttributePath = cty.GetAttrPath("settings")
+			diags = append(diags, *d)
+		}
+	}
+
+	if arguments["x-queue-mode"] == "lazy" {
+		if d := featureLazyQueueMode.diagnostic(version, "The queue sets the argument 'x-queue-mode' to 'lazy', it uses"); d != nil {
+			d.AttributePath = cty.GetAttrPath("settings")
+			diags = append(diags, *d)
+		}
+	}
+
+	return diags
+}
+
+// get the warnings about the deprecated features used on the broker (e.g. by the client applications)
+func usedDeprecatedFeatures(m *Meta, version string) diag.Diagnostics {
+	// the deprecated features are listed by the API since RabbitMQ 3.13
+	if !isVersionAtLeast(version, "3.13") {
+		return nil
+	}
+
+	var used []struct {
+		Name        string `json:"name"`
+		Description string `json:"desc"`
+	}
+	if err := apiRequest(m, "GET", "deprecated-features/used", nil, &used); err != nil {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	for _, u := range used {
+		d := &diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The deprecated feature '%s' is used on the RabbitMQ broker", u.Name),
+			Detail:   fmt.Sprintf("%s It may be removed by the next versions of RabbitMQ. See https://www.rabbitmq.com/docs/deprecated-features", u.Description),
+		}
+		for _, f := range deprecatedFeatures {
+			if f.name == u.Name {
+				d.Detail = fmt.Sprintf("%s is deprecated since RabbitMQ %s. %s See %s", f.summary, f.deprecatedIn, f.alternative, f.link)
+			}
+		}
+		diags = append(diags, *d)
+	}
+
+	return diags
+}
+
+// the errors of the diagnostics are raised at plan time by a CustomizeDiff
+func deprecationsError(diags diag.Diagnostics) error {
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			return fmt.Errorf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+	return nil
+}
+
+// wrap a CRUD function to add the warnings about the deprecated features used by the resource
+func withDeprecationWarnings(f func(*schema.ResourceData, interface{}) error, deprecations func(*schema.ResourceData, string) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		var diags diag.Diagnostics
+		if m, ok := meta.(*Meta); ok {
+			for _, w := range deprecations(d, m.brokerInfo().version) {
+				if w.Severity == diag.Warning {
+					diags = append(diags, w)
+				}
+			}
+		}
+
+		if err := f(d, meta); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+
+		return diags
+	}
+}
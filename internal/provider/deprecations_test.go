@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestIsVersionAtLeast(t *testing.T) {
+	var tests = []struct {
+		current  string
+		version  string
+		expected bool
+	}{
+		{"4.0.5", "4.0", true},
+		{"4.1.0", "4.0", true},
+		{"3.13.7", "4.0", false},
+		{"3.9.0", "3.9", true},
+		{"3.12.0-rc.1", "3.12", false},
+		{"", "3.8", false},
+	}
+
+	for _, test := range tests {
+		if isVersionAtLeast(test.current, test.version) != test.expected {
+			t.Errorf("isVersionAtLeast failed for %s >= %s", test.current, test.version)
+		}
+	}
+}
+
+func TestPolicyDeprecations(t *testing.T) {
+	var tests = []struct {
+		definition map[string]interface{}
+		version    string
+		expected   []diag.Severity
+	}{
+		{map[string]interface{}{"ha-mode": "all", "ha-sync-mode": "automatic"}, "3.8.9", nil},
+		{map[string]interface{}{"ha-mode": "all", "ha-sync-mode": "automatic"}, "3.13.7", []diag.Severity{diag.Warning}},
+		{map[string]interface{}{"ha-mode": "all"}, "4.0.5", []diag.Severity{diag.Error}},
+		{map[string]interface{}{"queue-mode": "lazy"}, "3.11.0", nil},
+		{map[string]interface{}{"queue-mode": "lazy"}, "4.1.0", []diag.Severity{diag.Warning}},
+		{map[string]interface{}{"queue-mode": "default", "max-length": 10}, "4.1.0", nil},
+		{map[string]interface{}{"ha-mode": "all", "queue-mode": "lazy"}, "", nil},
+	}
+
+	for _, test := range tests {
+		diags := policyDeprecations(test.definition, test.version)
+		if len(diags) != len(test.expected) {
+			t.Errorf("policyDeprecations failed for %#v on %s: %#v", test.definition, test.version, diags)
+			continue
+		}
+		for i, d := range diags {
+			if d.Severity != test.expected[i] {
+				t.Errorf("policyDeprecations failed for %#v on %s: %#v", test.definition, test.version, diags)
+			}
+		}
+	}
+
+	if deprecationsError(policyDeprecations(map[string]interface{}{"ha-mode": "all"}, "4.0.0")) == nil {
+		t.Errorf("deprecationsError should fail when a feature is removed")
+	}
+}
+
+func TestQueueDeprecations(t *testing.T) {
+	var tests = []struct {
+		durable   bool
+		arguments map[string]interface{}
+		version   string
+		expected  int
+	}{
+		{false, map[string]interface{}{}, "3.12.0", 0},
+		{false, map[string]interface{}{}, "3.13.0", 1},
+		{false, map[string]interface{}{"x-queue-type": "classic", "x-queue-mode": "lazy"}, "4.0.0", 2},
+		{true, map[string]interface{}{"x-queue-type": "quorum"}, "4.0.0", 0},
+		{true, map[string]interface{}{"x-queue-mode": "lazy"}, "3.12.0", 1},
+	}
+
+	for _, test := range tests {
+		diags := queueDeprecations(test.durable, test.arguments, test.version)
+		if len(diags) != test.expected || diags.HasError() {
+			t.Errorf("queueDeprecations failed for durable=%t %#v on %s: %#v", test.durable, test.arguments, test.version, diags)
+		}
+	}
+}
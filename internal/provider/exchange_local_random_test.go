@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestCheckExchangeLocalRandomVersion(t *testing.T) {
+	var tests = []struct {
+		version string
+		err     bool
+	}{
+		{"", false},
+		{"3.13.7", true},
+		{"4.0.0", false},
+		{"4.1.2", false},
+	}
+
+	for _, test := range tests {
+		if err := checkExchangeLocalRandomVersion(test.version); (err != nil) != test.err {
+			t.Errorf("checkExchangeLocalRandomVersion failed for %q: %v", test.version, err)
+		}
+	}
+}
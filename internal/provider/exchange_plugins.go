@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// fail at plan when the exchange type of a resource isn't known by the broker, naming the plugin which provides it
+func customizeExchangePluginDiff(exchangeType string, plugin string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+		if err := checkResourcePlugins(meta, plugin); err != nil {
+			return err
+		}
+		if m, ok := meta.(*Meta); ok {
+			return checkExchangeType(m.brokerInfo().exchangeTypes, exchangeType, plugin)
+		}
+		return nil
+	}
+}
+
+// the check is skipped when the exchange types of the broker are unknown
+func checkExchangeType(exchangeTypes []string, exchangeType string, plugin string) error {
+	if exchangeTypes != nil && !slices.Contains(exchangeTypes, exchangeType) {
+		return fmt.Errorf("the exchange type '%s' is not supported by the broker: the plugin '%s' must be enabled", exchangeType, plugin)
+	}
+	return nil
+}
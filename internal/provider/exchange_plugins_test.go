@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckExchangeType(t *testing.T) {
+	var tests = []struct {
+		exchangeTypes []string
+		exchangeType  string
+		err           bool
+	}{
+		{nil, "x-jms-topic", false},
+		{[]string{"direct", "x-jms-topic"}, "x-jms-topic", false},
+		{[]string{"direct", "topic"}, "x-jms-topic", true},
+		{[]string{}, "x-modulus-hash", true},
+	}
+
+	for _, test := range tests {
+		err := checkExchangeType(test.exchangeTypes, test.exchangeType, "rabbitmq_plugin")
+		if (err != nil) != test.err {
+			t.Errorf("checkExchangeType failed for %s in %v: %v", test.exchangeType, test.exchangeTypes, err)
+		}
+		if err != nil && !strings.Contains(err.Error(), "the plugin 'rabbitmq_plugin' must be enabled") {
+			t.Errorf("checkExchangeType failed: the error doesn't name the plugin: %v", err)
+		}
+	}
+}
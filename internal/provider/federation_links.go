@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+)
+
+const (
+	federationLinksStarting = "starting"
+	federationLinksRunning  = "running"
+	federationLinksError    = "error"
+	// the link was stopped, e.g. the upstream or the policy was removed
+	federationLinksShutdown = "shutdown"
+	// no link exists for the upstreams, e.g. no policy references them yet
+	federationLinksNone = "none"
+)
+
+// federationLink is the status of a federation link reported by the federation-links endpoint
+type federationLink struct {
+	id               string
+	node             string
+	upstream         string
+	linkType         string
+	exchange         string
+	queue            string
+	upstreamExchange string
+	upstreamQueue    string
+	status           string
+	error            string
+	timestamp        string
+}
+
+func parseFederationLinks(links rabbithole.FederationLinkMap) []federationLink {
+	parsed := make([]federationLink, len(links))
+
+	for i, l := range links {
+		get := func(key string) string {
+			if v, ok := l[key]; ok && v != nil {
+				if s, ok := v.(string); ok {
+					return s
+				}
+				return fmt.Sprint(v)
+			}
+			return ""
+		}
+
+		parsed[i] = federationLink{
+			id:               get("id"),
+			node:             get("node"),
+			upstream:         get("upstream"),
+			linkType:         get("type"),
+			exchange:         get("exchange"),
+			queue:            get("queue"),
+			upstreamExchange: get("upstream_exchange"),
+			upstreamQueue:    get("upstream_queue"),
+			status:           get("status"),
+			error:            get("error"),
+			timestamp:        get("timestamp"),
+		}
+	}
+
+	return parsed
+}
+
+// get the state of the links of the upstreams, an error is returned when a link failed or was shut down
+func federationLinksState(links []federationLink, upstreams []string) (string, error) {
+	state := federationLinksNone
+
+	for _, l := range links {
+		if !slices.Contains(upstreams, l.upstream) {
+			continue
+		}
+
+		name := l.exchange
+		if l.linkType == "queue" {
+			name = l.queue
+		}
+
+		switch l.status {
+		case federationLinksRunning:
+			if state == federationLinksNone {
+				state = federationLinksRunning
+			}
+		case federationLinksStarting:
+			state = federationLinksStarting
+		case federationLinksError:
+			return "", fmt.Errorf("the federation link of upstream '%s' for %s '%s' failed on node '%s': %s", l.upstream, l.linkType, name, l.node, strings.TrimSpace(l.error))
+		case federationLinksShutdown:
+			return "", fmt.Errorf("the federation link of upstream '%s' for %s '%s' was shut down on node '%s'", l.upstream, l.linkType, name, l.node)
+		default:
+			log.Printf("[DEBUG] RabbitMQ: Unknown status '%s' of the federation link of upstream %s for %s %s, waiting for the link", l.status, l.upstream, l.linkType, name)
+			state = federationLinksStarting
+		}
+	}
+
+	return state, nil
+}
+
+// wait until the links of the upstreams are running, fail with the error of a link. The wait lasts until the links
+// exist, they are created by the policies referencing the upstreams.
+func waitForFederationLinks(ctx context.Context, rmqc *rabbithole.Client, vhost string, upstreams []string, timeout time.Duration) error {
+	log.Printf("[DEBUG] RabbitMQ: Waiting for the federation links of upstreams %v in vhost %s", upstreams, vhost)
+
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{federationLinksStarting, federationLinksNone},
+		Target:  []string{federationLinksRunning},
+		Refresh: func() (interface{}, string, error) {
+			links, err := rmqc.ListFederationLinksIn(vhost)
+			if err != nil {
+				return nil, "", err
+			}
+
+			state, err := federationLinksState(parseFederationLinks(links), upstreams)
+			return links, state, err
+		},
+		Timeout:                   timeout,
+		MinTimeout:                time.Second,
+		ContinuousTargetOccurence: 2,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for the federation links of upstreams '%s' in vhost '%s': %v", strings.Join(upstreams, ", "), vhost, err)
+	}
+
+	return nil
+}
+
+// wait until the links of an upstream are running when a policy of the vhost already references the upstream. The
+// links of the policies declared after the upstream, e.g. in the same apply, are waited for by the policy.
+func waitForUpstreamLinks(ctx context.Context, rmqc *rabbithole.Client, vhost string, upstream string, timeout time.Duration) error {
+	policies, err := rmqc.ListPoliciesIn(vhost)
+	if err != nil {
+		return fmt.Errorf("error retrieving the policies of vhost '%s': %v", vhost, err)
+	}
+
+	sets := func(set string) []string {
+		parameter, err := rmqc.GetRuntimeParameter(federationUpstreamSetComponent, vhost, set)
+		if err != nil {
+			log.Printf("[WARN] RabbitMQ: unable to retrieve the federation upstream set %s@%s: %v", set, vhost, err)
+			return nil
+		}
+		return upstreamSetMembers(parameter.Value)
+	}
+
+	for _, policy := range policies {
+		if federatesWithUpstream(policy.Definition, upstream, sets) {
+			return waitForFederationLinks(ctx, rmqc, vhost, []string{upstream}, timeout)
+		}
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: No policy references the federation upstream %s@%s yet, no link to wait for", upstream, vhost)
+	return nil
+}
+
+// whether a policy definition federates with the upstream: directly, with all the upstreams of the vhost or with an
+// upstream set whose members are given by 'sets'
+func federatesWithUpstream(definition map[string]interface{}, upstream string, sets func(string) []string) bool {
+	if v, _ := definition["federation-upstream"].(string); v == upstream {
+		return true
+	}
+
+	switch set, _ := definition["federation-upstream-set"].(string); set {
+	case "":
+		return false
+	case "all":
+		return true
+	default:
+		return slices.Contains(sets(set), upstream)
+	}
+}
+
+// the upstreams of the value of a federation upstream set parameter
+func upstreamSetMembers(value interface{}) []string {
+	var upstreams []string
+
+	members, _ := value.([]interface{})
+	for _, m := range members {
+		if member, ok := m.(map[string]interface{}); ok {
+			if upstream, ok := member["upstream"].(string); ok {
+				upstreams = append(upstreams, upstream)
+			}
+		}
+	}
+
+	return upstreams
+}
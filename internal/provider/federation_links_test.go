@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+)
+
+func TestFederationLinksState(t *testing.T) {
+	links := parseFederationLinks(rabbithole.FederationLinkMap{
+		{"upstream": "eu", "type": "exchange", "exchange": "events", "status": "running", "node": "rabbit@a"},
+		{"upstream": "eu", "type": "queue", "queue": "jobs", "status": "running", "node": "rabbit@a"},
+		{"upstream": "us", "type": "exchange", "exchange": "events", "status": "starting", "node": "rabbit@a"},
+		{"upstream": "asia", "type": "queue", "queue": "jobs", "status": "running", "node": "rabbit@a"},
+		{"upstream": "asia", "type": "queue", "queue": "orders", "status": "error", "node": "rabbit@b", "error": "{auth_failure,\"ACCESS_REFUSED\"}\n"},
+		{"upstream": "us", "type": "exchange", "exchange": "orders", "status": "running", "node": "rabbit@a"},
+		{"upstream": "apac", "type": "exchange", "exchange": "events", "status": "shutdown", "node": "rabbit@c"},
+		{"upstream": "latam", "type": "exchange", "exchange": "events", "status": "unknown", "node": "rabbit@a"},
+	})
+
+	var tests = []struct {
+		upstreams []string
+		state     string
+		err       string
+	}{
+		{[]string{"eu"}, federationLinksRunning, ""},
+		{[]string{"us"}, federationLinksStarting, ""},
+		{[]string{"eu", "us"}, federationLinksStarting, ""},
+		{[]string{"latam"}, federationLinksStarting, ""},
+		{[]string{"asia"}, "", "for queue 'orders' failed on node 'rabbit@b': {auth_failure,\"ACCESS_REFUSED\"}"},
+		{[]string{"apac"}, "", "for exchange 'events' was shut down on node 'rabbit@c'"},
+		{[]string{"none"}, federationLinksNone, ""},
+		{nil, federationLinksNone, ""},
+	}
+
+	for _, test := range tests {
+		state, err := federationLinksState(links, test.upstreams)
+		if test.err != "" {
+			if err == nil || !strings.HasSuffix(err.Error(), test.err) {
+				t.Errorf("federationLinksState should fail for %v: %v", test.upstreams, err)
+			}
+			continue
+		}
+		if err != nil || state != test.state {
+			t.Errorf("federationLinksState failed for %v: expected %s, got %s (%v)", test.upstreams, test.state, state, err)
+		}
+	}
+}
+
+func TestFederatesWithUpstream(t *testing.T) {
+	sets := func(set string) []string {
+		if set == "regions" {
+			return []string{"eu", "us"}
+		}
+		return nil
+	}
+
+	var tests = []struct {
+		definition map[string]interface{}
+		expected   bool
+	}{
+		{map[string]interface{}{"federation-upstream": "eu"}, true},
+		{map[string]interface{}{"federation-upstream": "us"}, false},
+		{map[string]interface{}{"federation-upstream-set": "all"}, true},
+		{map[string]interface{}{"federation-upstream-set": "regions"}, true},
+		{map[string]interface{}{"federation-upstream-set": "unknown"}, false},
+		{map[string]interface{}{"max-length": 10}, false},
+	}
+
+	for _, test := range tests {
+		if actual := federatesWithUpstream(test.definition, "eu", sets); actual != test.expected {
+			t.Errorf("federatesWithUpstream failed for %#v: expected %t, got %t", test.definition, test.expected, actual)
+		}
+	}
+}
+
+func TestUpstreamSetMembers(t *testing.T) {
+	value := []interface{}{
+		map[string]interface{}{"upstream": "eu", "prefetch-count": float64(10)},
+		map[string]interface{}{"upstream": "us"},
+		"invalid",
+	}
+
+	if actual := upstreamSetMembers(value); !slices.Equal(actual, []string{"eu", "us"}) {
+		t.Errorf("upstreamSetMembers failed: %#v", actual)
+	}
+	if actual := upstreamSetMembers(nil); actual != nil {
+		t.Errorf("upstreamSetMembers failed for nil: %#v", actual)
+	}
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+)
+
+func TestExpandFederationUpstreamSet(t *testing.T) {
+	ty := resourceFederationUpstreamSet().CoreConfigSchema().ImpliedType()
+	raw, err := ctyjson.Unmarshal([]byte(`{"upstream": [{"name": "us", "max_hops": 2, "trust_user_id": false}, {"name": "eu"}]}`), ty)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	members := expandFederationUpstreamSet(raw, []interface{}{
+		map[string]interface{}{"name": "us", "max_hops": 2, "trust_user_id": false, "prefetch_count": 0, "exchange": ""},
+		map[string]interface{}{"name": "eu", "max_hops": 0, "trust_user_id": false, "prefetch_count": 0, "exchange": ""},
+	})
+
+	expected := []map[string]interface{}{
+		{"upstream": "us", "max-hops": 2, "trust-user-id": false},
+		{"upstream": "eu"},
+	}
+	if !reflect.DeepEqual(members, expected) {
+		t.Errorf("expandFederationUpstreamSet failed: expected %#v, got %#v", expected, members)
+	}
+}
+
+func TestFlattenFederationUpstreamSet(t *testing.T) {
+	upstreams := flattenFederationUpstreamSet([]interface{}{
+		map[string]interface{}{"upstream": "us", "max-hops": float64(2), "exchange": "events", "unknown": "x"},
+		map[string]interface{}{"upstream": "eu"},
+	})
+
+	expected := []map[string]interface{}{
+		{"name": "us", "max_hops": 2, "exchange": "events"},
+		{"name": "eu"},
+	}
+	if !reflect.DeepEqual(upstreams, expected) {
+		t.Errorf("flattenFederationUpstreamSet failed: expected %#v, got %#v", expected, upstreams)
+	}
+}
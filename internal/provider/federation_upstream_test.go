@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+)
+
+func TestExpandFederationUpstreamDefinition(t *testing.T) {
+	ty := resourceFederationUpstream().CoreConfigSchema().ImpliedType()
+	raw, err := ctyjson.Unmarshal([]byte(`{"name": "test", "vhost": "test", "definition": [{"uris": ["amqp://a", "amqp://b"], "queue_type": "quorum", "bind_nowait": false}]}`), ty)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	definition := expandFederationUpstreamDefinition(getRawBlock(raw, "definition"), map[string]interface{}{
+		"uri":                   "",
+		"uris":                  []interface{}{"amqp://a", "amqp://b"},
+		"prefetch_count":        1000,
+		"reconnect_delay":       5,
+		"ack_mode":              "on-confirm",
+		"trust_user_id":         false,
+		"exchange":              "",
+		"max_hops":              1,
+		"expires":               0,
+		"message_ttl":           0,
+		"queue":                 "",
+		"queue_type":            "quorum",
+		"bind_nowait":           false,
+		"resource_cleanup_mode": "",
+		"channel_use_mode":      "",
+		"consumer_tag":          "",
+	})
+
+	expected := map[string]interface{}{
+		"uri":             []string{"amqp://a", "amqp://b"},
+		"prefetch-count":  1000,
+		"reconnect-delay": 5,
+		"ack-mode":        "on-confirm",
+		"trust-user-id":   false,
+		"max-hops":        1,
+		"queue-type":      "quorum",
+		"bind-nowait":     false,
+	}
+	if !reflect.DeepEqual(definition, expected) {
+		t.Errorf("expandFederationUpstreamDefinition failed: expected %#v, got %#v", expected, definition)
+	}
+}
+
+func TestFlattenFederationUpstreamDefinition(t *testing.T) {
+	value := map[string]interface{}{
+		"uri":              []interface{}{"amqp://a"},
+		"prefetch-count":   float64(1000),
+		"trust-user-id":    false,
+		"channel-use-mode": "single",
+	}
+
+	var tests = []struct {
+		value    map[string]interface{}
+		useUris  bool
+		expected map[string]interface{}
+	}{
+		{value, false, map[string]interface{}{"uri": "amqp://a", "prefetch_count": 1000, "trust_user_id": false, "channel_use_mode": "single"}},
+		{value, true, map[string]interface{}{"uris": []string{"amqp://a"}, "prefetch_count": 1000, "trust_user_id": false, "channel_use_mode": "single"}},
+		{map[string]interface{}{"uri": []interface{}{"amqp://a", "amqp://b"}}, false, map[string]interface{}{"uris": []string{"amqp://a", "amqp://b"}}},
+		{map[string]interface{}{"uri": "amqp://a"}, false, map[string]interface{}{"uri": "amqp://a"}},
+	}
+
+	for _, test := range tests {
+		defMap := flattenFederationUpstreamDefinition(test.value, test.useUris, nil, nil)
+		if !reflect.DeepEqual(defMap, test.expected) {
+			t.Errorf("flattenFederationUpstreamDefinition failed: expected %#v, got %#v", test.expected, defMap)
+		}
+	}
+
+	secret := map[string]interface{}{"uri": []interface{}{"amqp://guest:secret@a", "amqp://guest:secret@b"}}
+	defMap := flattenFederationUpstreamDefinition(secret, false, nil, nil)
+	expected := map[string]interface{}{"uris": []string{"amqp://guest:********@a", "amqp://guest:********@b"}}
+	if !reflect.DeepEqual(defMap, expected) {
+		t.Errorf("flattenFederationUpstreamDefinition failed: expected %#v, got %#v", expected, defMap)
+	}
+
+	defMap = flattenFederationUpstreamDefinition(secret, false, nil, []string{"amqp://guest:secret@a", "amqp://guest:secret@b"})
+	expected = map[string]interface{}{"uris": []string{"amqp://guest:secret@a", "amqp://guest:secret@b"}}
+	if !reflect.DeepEqual(defMap, expected) {
+		t.Errorf("flattenFederationUpstreamDefinition failed: expected %#v, got %#v", expected, defMap)
+	}
+
+	defMap = flattenFederationUpstreamDefinition(map[string]interface{}{"uri": []interface{}{"amqp://guest:secret@a"}}, false, map[string]interface{}{"password": "secret"}, nil)
+	expected = map[string]interface{}{"connection": []map[string]interface{}{{"hosts": []string{"a"}, "tls": false, "username": "guest", "password": "secret"}}}
+	if !reflect.DeepEqual(defMap, expected) {
+		t.Errorf("flattenFederationUpstreamDefinition failed: expected %#v, got %#v", expected, defMap)
+	}
+}
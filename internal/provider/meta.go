@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"net/http"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+)
+
+// Meta is the meta of the provider passed to the resources and the data sources: the RabbitMQ client,
+// the transport of its requests and the information about the broker it's connected to.
+type Meta struct {
+	Client *rabbithole.Client
+
+	// used to call the endpoints of the HTTP API which are not covered by rabbit-hole
+	transport http.RoundTripper
+	// retrieved when the provider is configured
+	broker *brokerInfo
+}
+
+// the information about the broker, empty when it couldn't be detected
+func (m *Meta) brokerInfo() *brokerInfo {
+	if m.broker == nil {
+		return &brokerInfo{}
+	}
+	return m.broker
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The plugins the resources which aren't built in RabbitMQ depend on, they are enabled on the brokers of the
+// acceptance tests by 'scripts/enabled_plugins'
+const (
+	pluginFederation             = "rabbitmq_federation"
+	pluginFederationManagement   = "rabbitmq_federation_management"
+	pluginShovel                 = "rabbitmq_shovel"
+	pluginShovelManagement       = "rabbitmq_shovel_management"
+	pluginDelayedMessageExchange = "rabbitmq_delayed_message_exchange"
+	pluginRandomExchange         = "rabbitmq_random_exchange"
+	pluginConsistentHashExchange = "rabbitmq_consistent_hash_exchange"
+	pluginJmsTopicExchange       = "rabbitmq_jms_topic_exchange"
+	pluginRecentHistoryExchange  = "rabbitmq_recent_history_exchange"
+	pluginSharding               = "rabbitmq_sharding"
+)
+
+// a node of the broker listed by the HTTP API
+type brokerNode struct {
+	Name    string `json:"name"`
+	Running bool   `json:"running"`
+	// the plugins enabled explicitly, i.e. the content of the 'enabled_plugins' file
+	EnabledPlugins []string `json:"enabled_plugins"`
+	// the applications running on the node, including the plugins enabled as a dependency of another plugin
+	Applications []struct {
+		Name string `json:"name"`
+	} `json:"applications"`
+}
+
+// get the plugins enabled on the running nodes of the broker, nil when they can't be listed (e.g. by a user
+// without the 'monitoring' tag)
+func fetchEnabledPlugins(m *Meta) []string {
+	var nodes []brokerNode
+	if err := apiRequest(m, "GET", "nodes", nil, &nodes); err != nil {
+		log.Printf("[WARN] RabbitMQ: unable to list the plugins enabled on the broker: %v", err)
+		return nil
+	}
+
+	return enabledPlugins(nodes)
+}
+
+// the plugins enabled on the running nodes, explicitly or as a dependency: 'rabbitmq_shovel' runs when only
+// 'rabbitmq_shovel_management' is enabled
+func enabledPlugins(nodes []brokerNode) []string {
+	plugins := []string{}
+	add := func(plugin string) {
+		if !slices.Contains(plugins, plugin) {
+			plugins = append(plugins, plugin)
+		}
+	}
+
+	for _, n := range nodes {
+		if !n.Running {
+			continue
+		}
+		for _, p := range n.EnabledPlugins {
+			add(p)
+		}
+		for _, a := range n.Applications {
+			add(a.Name)
+		}
+	}
+	return plugins
+}
+
+// fail at plan when a plugin a resource depends on isn't enabled on the broker
+func customizePluginDiff(plugins ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+		return checkResourcePlugins(meta, plugins...)
+	}
+}
+
+func checkResourcePlugins(meta interface{}, plugins ...string) error {
+	if m, ok := meta.(*Meta); ok {
+		enabled := m.brokerInfo().plugins
+		for _, plugin := range plugins {
+			if err := checkPlugin(enabled, plugin); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// the check is skipped when the plugins of the broker are unknown
+func checkPlugin(enabled []string, plugin string) error {
+	if enabled != nil && !slices.Contains(enabled, plugin) {
+		return fmt.Errorf("the plugin '%s' is not enabled on the broker: enable it with 'rabbitmq-plugins enable %s'", plugin, plugin)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCheckPlugin(t *testing.T) {
+	var tests = []struct {
+		enabled []string
+		plugin  string
+		err     bool
+	}{
+		{nil, pluginShovel, false},
+		{[]string{pluginShovel, pluginShovelManagement}, pluginShovel, false},
+		{[]string{pluginFederation}, pluginShovel, true},
+		{[]string{}, pluginDelayedMessageExchange, true},
+	}
+
+	for _, test := range tests {
+		err := checkPlugin(test.enabled, test.plugin)
+		if (err != nil) != test.err {
+			t.Errorf("checkPlugin failed for %s in %v: %v", test.plugin, test.enabled, err)
+		}
+		if err != nil && !strings.Contains(err.Error(), "'"+test.plugin+"'") {
+			t.Errorf("checkPlugin failed: the error doesn't name the plugin: %v", err)
+		}
+	}
+}
+
+func TestEnabledPlugins(t *testing.T) {
+	var nodes []brokerNode
+	err := json.Unmarshal([]byte(`[
+		{"name": "rabbit@a", "running": true, "enabled_plugins": ["rabbitmq_management", "rabbitmq_shovel_management"],
+			"applications": [{"name": "rabbit"}, {"name": "rabbitmq_management"}, {"name": "rabbitmq_shovel"}, {"name": "rabbitmq_shovel_management"}]},
+		{"name": "rabbit@b", "running": false, "enabled_plugins": ["rabbitmq_federation_management"],
+			"applications": [{"name": "rabbitmq_federation"}, {"name": "rabbitmq_federation_management"}]}
+	]`), &nodes)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	enabled := enabledPlugins(nodes)
+
+	// the shovel plugin is only enabled as a dependency of its management plugin
+	for _, plugin := range []string{pluginShovel, pluginShovelManagement} {
+		if err := checkPlugin(enabled, plugin); err != nil {
+			t.Errorf("enabledPlugins failed: %v", err)
+		}
+	}
+	// the plugins of the nodes which aren't running are ignored
+	if err := checkPlugin(enabled, pluginFederation); err == nil {
+		t.Errorf("enabledPlugins failed: '%s' is only enabled on a stopped node", pluginFederation)
+	}
+}
+
+func TestEnabledPluginsMatchTheTestBrokers(t *testing.T) {
+	// the plugins of the resources are the ones enabled on the brokers of the acceptance tests
+	data, err := os.ReadFile("../../scripts/enabled_plugins")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	for _, plugin := range []string{pluginFederation, pluginFederationManagement, pluginShovel, pluginShovelManagement, pluginDelayedMessageExchange, pluginRandomExchange, pluginConsistentHashExchange, pluginJmsTopicExchange, pluginRecentHistoryExchange, pluginSharding} {
+		if !strings.Contains(string(data), plugin+",") && !strings.Contains(string(data), plugin+"]") {
+			t.Errorf("the plugin '%s' is not enabled by scripts/enabled_plugins", plugin)
+		}
+	}
+}
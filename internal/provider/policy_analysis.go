@@ -0,0 +1,236 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/utils"
+)
+
+const (
+	policyFindingOverlap  = "overlap"
+	policyFindingShadowed = "shadowed"
+	policyFindingClamped  = "clamped"
+)
+
+// policyFinding is a conflict between two policies of a vhost
+type policyFinding struct {
+	findingType string
+	operator    bool
+	policy      string
+	otherPolicy string
+	key         string
+	message     string
+}
+
+// the kinds of objects a policy can apply to, as the 'kind' and the 'queue type' of policyAppliesTo
+var policyTargetKinds = [][2]string{
+	{"exchange", ""},
+	{"queue", "classic"},
+	{"queue", "quorum"},
+	{"queue", "stream"},
+}
+
+// get the kinds of objects targeted by the 'apply-to' of a policy
+func policyTargets(applyTo string) []int {
+	var targets []int
+	for i, t := range policyTargetKinds {
+		if policyAppliesTo(applyTo, t[0], t[1]) {
+			targets = append(targets, i)
+		}
+	}
+	return targets
+}
+
+// describe the objects targeted by both policies
+func policyCommonTargets(a string, b string) string {
+	exchanges, queues := false, false
+	targetsB := policyTargets(b)
+	for _, t := range policyTargets(a) {
+		if slices.Contains(targetsB, t) {
+			if policyTargetKinds[t][0] == "exchange" {
+				exchanges = true
+			} else {
+				queues = true
+			}
+		}
+	}
+
+	switch {
+	case exchanges && queues:
+		return "queues and exchanges"
+	case exchanges:
+		return "exchanges"
+	case queues:
+		return "queues"
+	}
+	return ""
+}
+
+// all the objects targeted by 'b' are targeted by 'a'
+func policyTargetsInclude(a string, b string) bool {
+	targetsA := policyTargets(a)
+	for _, t := range policyTargets(b) {
+		if !slices.Contains(targetsA, t) {
+			return false
+		}
+	}
+	return true
+}
+
+// analyze the policies and the operator policies of a vhost. The patterns which can't be analyzed are reported as warnings.
+func analyzePolicies(policies []policyCandidate, operatorPolicies []policyCandidate) ([]policyFinding, diag.Diagnostics) {
+	policies, diags := analyzablePolicies(policies)
+	operatorPolicies, opDiags := analyzablePolicies(operatorPolicies)
+	diags = append(diags, opDiags...)
+
+	findings, pairDiags := analyzePolicyPairs(policies, false)
+	diags = append(diags, pairDiags...)
+
+	opFindings, pairDiags := analyzePolicyPairs(operatorPolicies, true)
+	findings = append(findings, opFindings...)
+	diags = append(diags, pairDiags...)
+
+	for _, p := range policies {
+		for _, o := range operatorPolicies {
+			if policyCommonTargets(p.applyTo, o.applyTo) == "" {
+				continue
+			}
+
+			overlap, err := utils.RegexOverlap(p.pattern, o.pattern)
+			if err != nil {
+				diags = append(diags, policyAnalysisWarning(err))
+				continue
+			}
+			if overlap {
+				findings = append(findings, operatorPolicyClamps(p, o)...)
+			}
+		}
+	}
+
+	return findings, diags
+}
+
+// keep the policies whose pattern can be evaluated by the provider, sorted by name
+func analyzablePolicies(candidates []policyCandidate) ([]policyCandidate, diag.Diagnostics) {
+	var analyzable []policyCandidate
+	var diags diag.Diagnostics
+
+	for _, c := range candidates {
+		if _, err := regexp.Compile(c.pattern); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Policy pattern not analyzed",
+				Detail:   fmt.Sprintf("The pattern %q of the policy '%s' uses a syntax which can't be evaluated by the provider, the policy is ignored: %v", c.pattern, c.name, err),
+			})
+			continue
+		}
+		analyzable = append(analyzable, c)
+	}
+
+	sort.SliceStable(analyzable, func(i, j int) bool { return analyzable[i].name < analyzable[j].name })
+
+	return analyzable, diags
+}
+
+// find the policies with the same priority matching the same objects, and the policies shadowed by a policy with a higher priority
+func analyzePolicyPairs(candidates []policyCandidate, operator bool) ([]policyFinding, diag.Diagnostics) {
+	var findings []policyFinding
+	var diags diag.Diagnostics
+
+	label, labels := "policy", "policies"
+	if operator {
+		label, labels = "operator policy", "operator policies"
+	}
+
+	for i, a := range candidates {
+		for _, b := range candidates[i+1:] {
+			targets := policyCommonTargets(a.applyTo, b.applyTo)
+			if targets == "" {
+				continue
+			}
+
+			if a.priority == b.priority {
+				overlap, err := utils.RegexOverlap(a.pattern, b.pattern)
+				if err != nil {
+					diags = append(diags, policyAnalysisWarning(err))
+					continue
+				}
+				if overlap {
+					findings = append(findings, policyFinding{
+						findingType: policyFindingOverlap,
+						operator:    operator,
+						policy:      a.name,
+						otherPolicy: b.name,
+						message:     fmt.Sprintf("The %s '%s' and '%s' have the same priority (%d) and their patterns can match the same %s, RabbitMQ doesn't define which one is applied.", labels, a.name, b.name, a.priority, targets),
+					})
+				}
+				continue
+			}
+
+			higher, lower := a, b
+			if b.priority > a.priority {
+				higher, lower = b, a
+			}
+			if !policyTargetsInclude(higher.applyTo, lower.applyTo) {
+				continue
+			}
+
+			shadowed, err := utils.RegexIncludes(higher.pattern, lower.pattern)
+			if err != nil {
+				diags = append(diags, policyAnalysisWarning(err))
+				continue
+			}
+			if shadowed {
+				findings = append(findings, policyFinding{
+					findingType: policyFindingShadowed,
+					operator:    operator,
+					policy:      lower.name,
+					otherPolicy: higher.name,
+					message:     fmt.Sprintf("The %s '%s' (priority %d) is never applied: the %s '%s' has a higher priority (%d) and matches all its %s.", label, lower.name, lower.priority, label, higher.name, higher.priority, targets),
+				})
+			}
+		}
+	}
+
+	return findings, diags
+}
+
+// find the values of a policy which are lowered by an operator policy matching the same queues
+func operatorPolicyClamps(policy policyCandidate, operatorPolicy policyCandidate) []policyFinding {
+	var findings []policyFinding
+
+	for _, key := range operatorPolicyClampedKeys {
+		value, isNumber := policy.definition[key].(float64)
+		if !isNumber {
+			continue
+		}
+		opValue, isNumber := operatorPolicy.definition[key].(float64)
+		if !isNumber || opValue >= value {
+			continue
+		}
+
+		findings = append(findings, policyFinding{
+			findingType: policyFindingClamped,
+			policy:      policy.name,
+			otherPolicy: operatorPolicy.name,
+			key:         key,
+			message: fmt.Sprintf("The operator policy '%s' clamps the key '%s' of the policy '%s' from %s to %s for the queues matched by both patterns.",
+				operatorPolicy.name, key, policy.name, strconv.FormatFloat(value, 'f', -1, 64), strconv.FormatFloat(opValue, 'f', -1, 64)),
+		})
+	}
+
+	return findings
+}
+
+func policyAnalysisWarning(err error) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "Policy patterns not compared",
+		Detail:   err.Error(),
+	}
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestPolicyCommonTargets(t *testing.T) {
+	var tests = []struct {
+		a        string
+		b        string
+		expected string
+	}{
+		{"all", "all", "queues and exchanges"},
+		{"all", "exchanges", "exchanges"},
+		{"queues", "quorum_queues", "queues"},
+		{"classic_queues", "quorum_queues", ""},
+		{"exchanges", "streams", ""},
+	}
+
+	for _, test := range tests {
+		if targets := policyCommonTargets(test.a, test.b); targets != test.expected {
+			t.Errorf("policyCommonTargets failed for %s and %s: got '%s', expected '%s'", test.a, test.b, targets, test.expected)
+		}
+	}
+}
+
+func TestAnalyzePolicies(t *testing.T) {
+	policies := []policyCandidate{
+		{name: "orders", pattern: "^orders\\.", applyTo: "queues", priority: 10, definition: map[string]interface{}{"max-length": float64(1000), "message-ttl": float64(60000)}},
+		{name: "orders-eu", pattern: "\\.eu$", applyTo: "queues", priority: 10, definition: map[string]interface{}{}},
+		{name: "orders-old", pattern: "^orders\\.old", applyTo: "quorum_queues", priority: 0, definition: map[string]interface{}{}},
+		{name: "invoices", pattern: "^invoices\\.", applyTo: "queues", priority: 10, definition: map[string]interface{}{}},
+		{name: "exchanges", pattern: "^orders\\.", applyTo: "exchanges", priority: 10, definition: map[string]interface{}{}},
+		{name: "invalid", pattern: "^(?!amq\\.).*", applyTo: "queues", priority: 10, definition: map[string]interface{}{}},
+	}
+	operatorPolicies := []policyCandidate{
+		{name: "clamp", pattern: ".*", applyTo: "queues", priority: 0, definition: map[string]interface{}{"max-length": float64(100), "message-ttl": float64(120000)}},
+		{name: "clamp-orders", pattern: "^orders", applyTo: "classic_queues", priority: 0, definition: map[string]interface{}{}},
+	}
+
+	findings, diags := analyzePolicies(policies, operatorPolicies)
+
+	if len(diags) != 1 || diags[0].Summary != "Policy pattern not analyzed" {
+		t.Errorf("analyzePolicies should report the pattern of the 'invalid' policy: %v", diags)
+	}
+
+	var expected = []struct {
+		findingType string
+		operator    bool
+		policy      string
+		otherPolicy string
+		key         string
+	}{
+		{policyFindingOverlap, false, "invoices", "orders-eu", ""},
+		{policyFindingOverlap, false, "orders", "orders-eu", ""},
+		{policyFindingShadowed, false, "orders-old", "orders", ""},
+		{policyFindingOverlap, true, "clamp", "clamp-orders", ""},
+		{policyFindingClamped, false, "orders", "clamp", "max-length"},
+	}
+
+	if len(findings) != len(expected) {
+		t.Fatalf("analyzePolicies returned %d findings, expected %d: %v", len(findings), len(expected), findings)
+	}
+
+	for i, e := range expected {
+		f := findings[i]
+		if f.findingType != e.findingType || f.operator != e.operator || f.policy != e.policy || f.otherPolicy != e.otherPolicy || f.key != e.key {
+			t.Errorf("analyzePolicies finding %d: got %v, expected %v", i, f, e)
+		}
+		if f.message == "" {
+			t.Errorf("analyzePolicies finding %d has no message", i)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var maxAgeRegexp = regexp.MustCompile(`^[0-9]+[YMDhms]$`)
+
+// policyAttribute links a typed attribute of a policy block to its key in the policy definition
+type policyAttribute struct {
+	attribute string
+	key       string
+	schema    *schema.Schema
+}
+
+var policyDefinitionAttributes = []policyAttribute{
+	{
+		attribute: "max_length",
+		key:       "max-length",
+		schema: &schema.Schema{
+			Description:  "The maximum number of messages in the queue (`max-length`).",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+	},
+	{
+		attribute: "message_ttl",
+		key:       "message-ttl",
+		schema: &schema.Schema{
+			Description:  "The time-to-live of the messages in milliseconds (`message-ttl`).",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+	},
+	{
+		attribute: "dead_letter_exchange",
+		key:       "dead-letter-exchange",
+		schema: &schema.Schema{
+			Description: "The exchange to which the dead-lettered messages are republished (`dead-letter-exchange`).",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+	},
+	{
+		attribute: "queue_mode",
+		key:       "queue-mode",
+		schema: &schema.Schema{
+			Description:  "The mode of a classic queue (`queue-mode`). Can either be `default` or `lazy`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"default", "lazy"}, false),
+		},
+	},
+	{
+		attribute: "federation_upstream_set",
+		key:       "federation-upstream-set",
+		schema: &schema.Schema{
+			Description: "The federation upstream set to use, `all` for all the upstreams (`federation-upstream-set`).",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+	},
+	{
+		attribute: "delivery_limit",
+		key:       "delivery-limit",
+		schema: &schema.Schema{
+			Description:  "The number of delivery attempts of a message in a quorum queue (`delivery-limit`). Use `-1` for unlimited.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(-1),
+		},
+	},
+	{
+		attribute: "max_age",
+		key:       "max-age",
+		schema: &schema.Schema{
+			Description:  "The maximum age of the messages in a stream (`max-age`), e.g. `7D` or `12h`. The units are `Y`, `M`, `D`, `h`, `m` and `s`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(maxAgeRegexp, "must be a number followed by a unit: Y, M, D, h, m or s"),
+		},
+	},
+}
+
+// add the attributes describing the policy definition to the schema of a policy block
+func policyDefinitionSchema(s map[string]*schema.Schema, block string) map[string]*schema.Schema {
+	definitions := []string{block + ".0.definition", block + ".0.definition_json"}
+	for _, a := range policyDefinitionAttributes {
+		definitions = append(definitions, block+".0."+a.attribute)
+	}
+
+	s["definition"] = &schema.Schema{
+		Description:      "Key/value pairs of the policy definition. The numbers are converted to integers and the comma-separated `ha-params` to a list of nodes.\n-> **Note:** See the RabbitMQ documentation for definition references and examples.",
+		Type:             schema.TypeMap,
+		Optional:         true,
+		ValidateDiagFunc: validatePolicyDefinitionKeys,
+		ConflictsWith:    []string{block + ".0.definition_json"},
+		AtLeastOneOf:     definitions,
+	}
+
+	s["definition_json"] = &schema.Schema{
+		Description:      "The policy definition as a JSON object, the values keep their type (booleans, lists, objects...).",
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validatePolicyDefinitionKeys,
+		DiffSuppressFunc: structure.SuppressJsonDiff,
+		ConflictsWith:    []string{block + ".0.definition"},
+		AtLeastOneOf:     definitions,
+	}
+
+	for _, a := range policyDefinitionAttributes {
+		attr := *a.schema
+		attr.AtLeastOneOf = definitions
+		s[a.attribute] = &attr
+	}
+
+	return s
+}
+
+// get the first element of a block from the raw config or state
+func getRawBlock(raw cty.Value, block string) cty.Value {
+	return getRawBlockElement(raw, block, 0)
+}
+
+// get an element of a list of blocks from the raw config or state
+func getRawBlockElement(raw cty.Value, block string, index int) cty.Value {
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().IsObjectType() || !raw.Type().HasAttribute(block) {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+
+	v := raw.GetAttr(block)
+	if v.IsNull() || !v.IsKnown() || !v.CanIterateElements() || v.LengthInt() <= index {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+
+	return v.Index(cty.NumberIntVal(int64(index)))
+}
+
+// an attribute is set when it's not null into the raw config or state
+func isRawAttributeSet(raw cty.Value, attribute string) bool {
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().IsObjectType() || !raw.Type().HasAttribute(attribute) {
+		return false
+	}
+
+	v := raw.GetAttr(attribute)
+	return !v.IsNull()
+}
+
+// build the policy definition from the 'definition' map, the 'definition_json' string and the typed attributes of a policy block
+func expandPolicyDefinition(d *schema.ResourceData, block string, policyMap map[string]interface{}) (map[string]interface{}, error) {
+	definition := make(map[string]interface{})
+	rawConfig := getRawBlock(d.GetRawConfig(), block)
+
+	if v, ok := policyMap["definition"].(map[string]interface{}); ok {
+		for key, val := range v {
+			definition[key] = val
+		}
+
+		// special case for ha-mode = nodes
+		if x, ok := definition["ha-mode"]; ok && x == "nodes" {
+			if params, ok := definition["ha-params"].(string); ok {
+				definition["ha-params"] = strings.Split(params, ",")
+			}
+		}
+
+		// special case for integers
+		for key, val := range definition {
+			if x, ok := val.(string); ok {
+				if x, err := strconv.ParseInt(x, 10, 64); err == nil {
+					definition[key] = x
+				}
+			}
+		}
+	}
+
+	if v, ok := policyMap["definition_json"].(string); ok && v != "" {
+		decoder := json.NewDecoder(bytes.NewBufferString(v))
+		// keep the numbers as they are written
+		decoder.UseNumber()
+		if err := decoder.Decode(&definition); err != nil {
+			return nil, fmt.Errorf("unable to parse 'definition_json', a JSON object is expected: %v", err)
+		}
+	}
+
+	// the keys set several times are rejected at plan time
+	for _, a := range policyDefinitionAttributes {
+		if isRawAttributeSet(rawConfig, a.attribute) {
+			definition[a.key] = policyMap[a.attribute]
+		}
+	}
+
+	return definition, nil
+}
+
+// split the policy definition retrieved from the server into the attributes of the policy block.
+// The typed attributes managed by the resource are set first, then the other keys are set into
+// 'definition_json' when it's used (or when a value can't be represented as a string) or into 'definition'.
+// The attributes are taken from the configuration when it's available, from the state otherwise.
+func flattenPolicyDefinition(d *schema.ResourceData, block string, definition map[string]interface{}, p map[string]interface{}) error {
+	raw := getRawBlock(d.GetRawConfig(), block)
+	if raw.IsNull() {
+		raw = getRawBlock(d.GetRawState(), block)
+	}
+
+	remaining := make(map[string]interface{})
+	for key, value := range definition {
+		remaining[key] = value
+	}
+
+	for _, a := range policyDefinitionAttributes {
+		if !isRawAttributeSet(raw, a.attribute) {
+			continue
+		}
+
+		value, ok := remaining[a.key]
+		if !ok {
+			continue
+		}
+		delete(remaining, a.key)
+
+		if a.schema.Type == schema.TypeInt {
+			if f, ok := value.(float64); ok {
+				value = int(f)
+			}
+		}
+		p[a.attribute] = value
+	}
+
+	jsonManaged := isRawAttributeSet(raw, "definition_json")
+	mapManaged := isRawAttributeSet(raw, "definition")
+
+	if jsonManaged || (!mapManaged && !isStringMapCompatible(remaining)) {
+		b, err := json.Marshal(remaining)
+		if err != nil {
+			return err
+		}
+		p["definition_json"] = string(b)
+		return nil
+	}
+
+	policyDefinition := make(map[string]interface{})
+	for key, value := range remaining {
+		switch v := value.(type) {
+		case float64:
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+		case []interface{}:
+			var nodes []string
+			for _, node := range v {
+				if n, ok := node.(string); ok {
+					nodes = append(nodes, n)
+				}
+			}
+			value = strings.Join(nodes, ",")
+		}
+		policyDefinition[key] = value
+	}
+	if len(policyDefinition) > 0 || mapManaged {
+		p["definition"] = policyDefinition
+	}
+
+	return nil
+}
+
+// the values of a definition can be represented by the 'definition' map without losing their type
+func isStringMapCompatible(definition map[string]interface{}) bool {
+	for key, value := range definition {
+		switch v := value.(type) {
+		case string:
+			if _, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return false
+			}
+		case float64:
+			if v != float64(int64(v)) {
+				return false
+			}
+		case []interface{}:
+			if key != "ha-params" {
+				return false
+			}
+			for _, node := range v {
+				if _, ok := node.(string); !ok {
+					return false
+				}
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
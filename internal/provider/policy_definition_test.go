@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// build the ResourceData of a policy with a raw config and a raw state given as JSON
+func testPolicyResourceData(t *testing.T, config string, state string) *schema.ResourceData {
+	r := resourcePolicy()
+	ty := r.CoreConfigSchema().ImpliedType()
+
+	s := &terraform.InstanceState{ID: "test@/"}
+	for _, raw := range []struct {
+		json  string
+		value *cty.Value
+	}{{config, &s.RawConfig}, {state, &s.RawState}} {
+		if raw.json == "" {
+			*raw.value = cty.NullVal(ty)
+			continue
+		}
+		v, err := ctyjson.Unmarshal([]byte(raw.json), ty)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		*raw.value = v
+	}
+
+	return r.Data(s)
+}
+
+func TestExpandPolicyDefinition(t *testing.T) {
+	d := testPolicyResourceData(t, `{"policy": [{"definition_json": "{\"x-flag\": true, \"max-age\": \"1D\"}", "max_length": 100}]}`, "")
+	definition, err := expandPolicyDefinition(d, "policy", map[string]interface{}{
+		"definition_json": `{"x-flag": true, "ha-params": ["a", "b"], "expires": 1800000}`,
+		"max_length":      100,
+		"message_ttl":     0,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"x-flag":     true,
+		"ha-params":  []interface{}{"a", "b"},
+		"expires":    json.Number("1800000"),
+		"max-length": 100,
+	}
+	if !reflect.DeepEqual(definition, expected) {
+		t.Errorf("expandPolicyDefinition failed: expected %#v, got %#v", expected, definition)
+	}
+
+	d = testPolicyResourceData(t, `{"policy": [{"definition": {"ha-mode": "nodes", "ha-params": "a,b", "max-length": "10"}}]}`, "")
+	definition, err = expandPolicyDefinition(d, "policy", map[string]interface{}{
+		"definition": map[string]interface{}{"ha-mode": "nodes", "ha-params": "a,b", "max-length": "10"},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected = map[string]interface{}{
+		"ha-mode":    "nodes",
+		"ha-params":  []string{"a", "b"},
+		"max-length": int64(10),
+	}
+	if !reflect.DeepEqual(definition, expected) {
+		t.Errorf("expandPolicyDefinition failed: expected %#v, got %#v", expected, definition)
+	}
+}
+
+func TestFlattenPolicyDefinition(t *testing.T) {
+	definition := map[string]interface{}{
+		"max-length":           float64(100),
+		"dead-letter-exchange": "dlx",
+		"x-flag":               true,
+	}
+
+	var tests = []struct {
+		state    string
+		expected map[string]interface{}
+	}{
+		{
+			// the typed attribute and the JSON definition are managed
+			`{"policy": [{"definition_json": "{}", "max_length": 10}]}`,
+			map[string]interface{}{"max_length": 100, "definition_json": `{"dead-letter-exchange":"dlx","x-flag":true}`},
+		},
+		{
+			// the typed attributes are managed, the unexpected boolean is set into the JSON definition
+			`{"policy": [{"max_length": 10, "dead_letter_exchange": "dlx"}]}`,
+			map[string]interface{}{"max_length": 100, "dead_letter_exchange": "dlx", "definition_json": `{"x-flag":true}`},
+		},
+		{
+			// import: the boolean can't be represented by the map
+			"",
+			map[string]interface{}{"definition_json": `{"dead-letter-exchange":"dlx","max-length":100,"x-flag":true}`},
+		},
+	}
+
+	for _, test := range tests {
+		d := testPolicyResourceData(t, "", test.state)
+		p := make(map[string]interface{})
+		if err := flattenPolicyDefinition(d, "policy", definition, p); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if !reflect.DeepEqual(p, test.expected) {
+			t.Errorf("flattenPolicyDefinition failed for %s: expected %#v, got %#v", test.state, test.expected, p)
+		}
+	}
+
+	d := testPolicyResourceData(t, "", "")
+	p := make(map[string]interface{})
+	if err := flattenPolicyDefinition(d, "policy", map[string]interface{}{"ha-mode": "nodes", "ha-params": []interface{}{"a", "b"}, "max-length": float64(10)}, p); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]interface{}{"definition": map[string]interface{}{"ha-mode": "nodes", "ha-params": "a,b", "max-length": "10"}}
+	if !reflect.DeepEqual(p, expected) {
+		t.Errorf("flattenPolicyDefinition failed: expected %#v, got %#v", expected, p)
+	}
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+)
+
+// policyCandidate is a policy or an operator policy which may apply to a queue or an exchange
+type policyCandidate struct {
+	name       string
+	pattern    string
+	applyTo    string
+	priority   int
+	definition map[string]interface{}
+}
+
+// The numeric keys of an operator policy clamp the value of the user policy: the lowest value is applied
+var operatorPolicyClampedKeys = []string{
+	"delivery-limit",
+	"expires",
+	"max-in-memory-bytes",
+	"max-in-memory-length",
+	"max-length",
+	"max-length-bytes",
+	"message-ttl",
+	"target-group-size",
+}
+
+func policyCandidates(policies []rabbithole.Policy) []policyCandidate {
+	candidates := make([]policyCandidate, len(policies))
+	for i, p := range policies {
+		candidates[i] = policyCandidate{name: p.Name, pattern: p.Pattern, applyTo: p.ApplyTo, priority: p.Priority, definition: p.Definition}
+	}
+	return candidates
+}
+
+func operatorPolicyCandidates(policies []rabbithole.OperatorPolicy) []policyCandidate {
+	candidates := make([]policyCandidate, len(policies))
+	for i, p := range policies {
+		candidates[i] = policyCandidate{name: p.Name, pattern: p.Pattern, applyTo: p.ApplyTo, priority: p.Priority, definition: p.Definition}
+	}
+	return candidates
+}
+
+// the 'apply-to' of a policy matches a kind of object ('queue' or 'exchange') and a queue type
+func policyAppliesTo(applyTo string, kind string, queueType string) bool {
+	switch applyTo {
+	case "all":
+		return true
+	case "exchanges":
+		return kind == "exchange"
+	case "queues":
+		return kind == "queue"
+	case "classic_queues":
+		return kind == "queue" && queueType == "classic"
+	case "quorum_queues":
+		return kind == "queue" && queueType == "quorum"
+	case "streams":
+		return kind == "queue" && queueType == "stream"
+	}
+	return false
+}
+
+// get the policies which match an object, sorted by decreasing priority. The policies with the same priority are
+// sorted by name, RabbitMQ doesn't define which one is applied. The patterns which can't be evaluated are reported as warnings.
+func matchingPolicies(candidates []policyCandidate, name string, kind string, queueType string) ([]policyCandidate, diag.Diagnostics) {
+	var matches []policyCandidate
+	var diags diag.Diagnostics
+
+	for _, c := range candidates {
+		if !policyAppliesTo(c.applyTo, kind, queueType) {
+			continue
+		}
+
+		re, err := regexp.Compile(c.pattern)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Policy pattern not evaluated",
+				Detail:   fmt.Sprintf("The pattern %q of the policy '%s' uses a syntax which can't be evaluated by the provider, the policy is ignored: %v", c.pattern, c.name, err),
+			})
+			continue
+		}
+
+		if re.MatchString(name) {
+			matches = append(matches, c)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].priority != matches[j].priority {
+			return matches[i].priority > matches[j].priority
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	return matches, diags
+}
+
+// merge the definition of an operator policy into the definition of a user policy
+func mergeOperatorPolicyDefinition(definition map[string]interface{}, operatorDefinition map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for key, value := range definition {
+		merged[key] = value
+	}
+
+	for key, opValue := range operatorDefinition {
+		value, ok := merged[key]
+		if ok && slices.Contains(operatorPolicyClampedKeys, key) {
+			if v, isNumber := value.(float64); isNumber {
+				if op, isNumber := opValue.(float64); isNumber && v < op {
+					continue
+				}
+			}
+		}
+		merged[key] = opValue
+	}
+
+	return merged
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPolicyAppliesTo(t *testing.T) {
+	var tests = []struct {
+		applyTo   string
+		kind      string
+		queueType string
+		expected  bool
+	}{
+		{"all", "exchange", "", true},
+		{"all", "queue", "stream", true},
+		{"exchanges", "exchange", "", true},
+		{"exchanges", "queue", "classic", false},
+		{"queues", "queue", "quorum", true},
+		{"queues", "exchange", "", false},
+		{"classic_queues", "queue", "classic", true},
+		{"classic_queues", "queue", "quorum", false},
+		{"quorum_queues", "queue", "quorum", true},
+		{"streams", "queue", "stream", true},
+		{"streams", "queue", "classic", false},
+	}
+
+	for _, test := range tests {
+		if policyAppliesTo(test.applyTo, test.kind, test.queueType) != test.expected {
+			t.Errorf("policyAppliesTo failed for %s on %s (%s)", test.applyTo, test.kind, test.queueType)
+		}
+	}
+}
+
+func TestResolveEffectivePolicy(t *testing.T) {
+	policies := []policyCandidate{
+		{name: "all", pattern: ".*", applyTo: "all", priority: 0, definition: map[string]interface{}{"federation-upstream-set": "all"}},
+		{name: "orders", pattern: "^orders\\.", applyTo: "queues", priority: 10, definition: map[string]interface{}{"message-ttl": float64(60000), "max-length": float64(100)}},
+		{name: "orders-quorum", pattern: "^orders\\.", applyTo: "quorum_queues", priority: 10, definition: map[string]interface{}{"delivery-limit": float64(5)}},
+		{name: "invalid", pattern: "^(?!amq\\.).*", applyTo: "queues", priority: 100, definition: map[string]interface{}{"expires": float64(1000)}},
+	}
+	operatorPolicies := []policyCandidate{
+		{name: "clamp", pattern: ".*", applyTo: "queues", priority: 0, definition: map[string]interface{}{"message-ttl": float64(30000), "max-length": float64(1000)}},
+	}
+
+	var tests = []struct {
+		name           string
+		kind           string
+		queueType      string
+		policy         string
+		operatorPolicy string
+		definition     map[string]interface{}
+	}{
+		{"orders.eu", "queue", "classic", "orders", "clamp", map[string]interface{}{"message-ttl": float64(30000), "max-length": float64(100)}},
+		// the policies with the same priority are sorted by name
+		{"orders.eu", "queue", "quorum", "orders", "clamp", map[string]interface{}{"message-ttl": float64(30000), "max-length": float64(100)}},
+		{"invoices", "queue", "stream", "all", "clamp", map[string]interface{}{"federation-upstream-set": "all", "message-ttl": float64(30000), "max-length": float64(1000)}},
+		{"orders.eu", "exchange", "", "all", "", map[string]interface{}{"federation-upstream-set": "all"}},
+	}
+
+	for _, test := range tests {
+		policy, operatorPolicy, definition, diags := resolveEffectivePolicy(policies, operatorPolicies, test.name, test.kind, test.queueType)
+
+		if policy != test.policy || operatorPolicy != test.operatorPolicy || !reflect.DeepEqual(definition, test.definition) {
+			t.Errorf("resolveEffectivePolicy failed for %s %s (%s): got %s, %s, %#v", test.kind, test.name, test.queueType, policy, operatorPolicy, definition)
+		}
+
+		// the pattern with a lookahead can't be evaluated
+		if test.kind == "queue" && len(diags) != 1 {
+			t.Errorf("resolveEffectivePolicy should warn about the pattern which can't be evaluated: %#v", diags)
+		}
+	}
+
+	policy, operatorPolicy, definition, _ := resolveEffectivePolicy(nil, nil, "orders.eu", "queue", "classic")
+	if policy != "" || operatorPolicy != "" || len(definition) != 0 {
+		t.Errorf("resolveEffectivePolicy should not resolve any policy: %s, %s, %#v", policy, operatorPolicy, definition)
+	}
+}
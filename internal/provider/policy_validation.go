@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	policyValueInteger = "integer"
+	policyValueString  = "string"
+	policyValueAny     = "any"
+
+	policyTargetQueues    = "queues"
+	policyTargetExchanges = "exchanges"
+	policyTargetAll       = "all"
+)
+
+var policyApplyTo = []string{"exchanges", "queues", "all", "classic_queues", "quorum_queues", "streams"}
+var operatorPolicyApplyTo = []string{"queues", "classic_queues", "quorum_queues", "streams"}
+
+// policyKey describes a key of a policy definition known by RabbitMQ
+type policyKey struct {
+	valueType string
+	values    []string
+	target    string
+	operator  bool
+}
+
+var policyKeys = map[string]policyKey{
+	"alternate-exchange":            {valueType: policyValueString, target: policyTargetExchanges},
+	"consumer-timeout":              {valueType: policyValueInteger, target: policyTargetQueues},
+	"dead-letter-exchange":          {valueType: policyValueString, target: policyTargetQueues},
+	"dead-letter-routing-key":       {valueType: policyValueString, target: policyTargetQueues},
+	"dead-letter-strategy":          {valueType: policyValueString, target: policyTargetQueues, values: []string{"at-most-once", "at-least-once"}},
+	"delivery-limit":                {valueType: policyValueInteger, target: policyTargetQueues, operator: true},
+	"expires":                       {valueType: policyValueInteger, target: policyTargetQueues, operator: true},
+	"federation-upstream":           {valueType: policyValueString, target: policyTargetAll},
+	"federation-upstream-set":       {valueType: policyValueString, target: policyTargetAll},
+	"ha-mode":                       {valueType: policyValueString, target: policyTargetQueues, values: []string{"all", "exactly", "nodes"}},
+	"ha-params":                     {valueType: policyValueAny, target: policyTargetQueues},
+	"ha-promote-on-failure":         {valueType: policyValueString, target: policyTargetQueues, values: []string{"always", "when-synced"}},
+	"ha-promote-on-shutdown":        {valueType: policyValueString, target: policyTargetQueues, values: []string{"always", "when-synced"}},
+	"ha-sync-batch-size":            {valueType: policyValueInteger, target: policyTargetQueues},
+	"ha-sync-mode":                  {valueType: policyValueString, target: policyTargetQueues, values: []string{"manual", "automatic"}},
+	"initial-cluster-size":          {valueType: policyValueInteger, target: policyTargetQueues},
+	"max-age":                       {valueType: policyValueString, target: policyTargetQueues},
+	"max-in-memory-bytes":           {valueType: policyValueInteger, target: policyTargetQueues, operator: true},
+	"max-in-memory-length":          {valueType: policyValueInteger, target: policyTargetQueues, operator: true},
+	"max-length":                    {valueType: policyValueInteger, target: policyTargetQueues, operator: true},
+	"max-length-bytes":              {valueType: policyValueInteger, target: policyTargetQueues, operator: true},
+	"message-ttl":                   {valueType: policyValueInteger, target: policyTargetQueues, operator: true},
+	"overflow":                      {valueType: policyValueString, target: policyTargetQueues, values: []string{"drop-head", "reject-publish", "reject-publish-dlx"}},
+	"queue-leader-locator":          {valueType: policyValueString, target: policyTargetQueues, values: []string{"client-local", "balanced"}},
+	"queue-master-locator":          {valueType: policyValueString, target: policyTargetQueues, values: []string{"client-local", "min-masters", "random"}},
+	"queue-mode":                    {valueType: policyValueString, target: policyTargetQueues, values: []string{"default", "lazy"}},
+	"queue-version":                 {valueType: policyValueInteger, target: policyTargetQueues},
+	"routing-key":                   {valueType: policyValueString, target: policyTargetExchanges},
+	"shards-per-node":               {valueType: policyValueInteger, target: policyTargetExchanges},
+	"stream-filter-size-bytes":      {valueType: policyValueInteger, target: policyTargetQueues},
+	"stream-max-segment-size-bytes": {valueType: policyValueInteger, target: policyTargetQueues},
+	"target-group-size":             {valueType: policyValueInteger, target: policyTargetQueues, operator: true},
+}
+
+// customizePolicyDiff checks the policy definition of a 'rabbitmq_policy' or a 'rabbitmq_operator_policy' resource
+// against the known keys. The values which are not known yet are not checked.
+func customizePolicyDiff(operator bool) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		raw := getRawBlock(d.GetRawConfig(), "policy")
+		if raw.IsNull() || !raw.IsKnown() {
+			return nil
+		}
+
+		definition, err := rawPolicyDefinition(raw)
+		if err != nil {
+			return err
+		}
+
+		applyTo := ""
+		if v := raw.GetAttr("apply_to"); v.IsKnown() && !v.IsNull() {
+			applyTo = v.AsString()
+		}
+
+		if err := checkPolicyDefinition(definition, applyTo, operator); err != nil {
+			return err
+		}
+
+		// the removed features are rejected, the deprecated ones are reported when the policy is applied
+		if m, ok := meta.(*Meta); ok && !operator {
+			return deprecationsError(policyDeprecations(definition, m.brokerInfo().version))
+		}
+
+		return nil
+	}
+}
+
+// check the keys of a policy definition, their value and the kind of objects they apply to
+func checkPolicyDefinition(definition map[string]interface{}, applyTo string, operator bool) error {
+	keys := make([]string, 0, len(definition))
+	for key := range definition {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		k, ok := policyKeys[key]
+		if !ok {
+			// unknown keys are reported as warnings by the validation of the attributes
+			continue
+		}
+
+		if operator && !k.operator {
+			return fmt.Errorf("the key '%s' can't be used in an operator policy, the supported keys are: %s", key, strings.Join(operatorPolicyKeys(), ", "))
+		}
+
+		if applyTo == "exchanges" && k.target == policyTargetQueues {
+			return fmt.Errorf("the key '%s' applies to queues, it can't be used in a policy applied to exchanges", key)
+		}
+		if applyTo != "" && applyTo != "all" && applyTo != "exchanges" && k.target == policyTargetExchanges {
+			return fmt.Errorf("the key '%s' applies to exchanges, it can't be used in a policy applied to %s", key, strings.ReplaceAll(applyTo, "_", " "))
+		}
+
+		if err := checkPolicyValue(key, k, definition[key]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// get the known values of the policy definition from the raw config of a policy block
+func rawPolicyDefinition(raw cty.Value) (map[string]interface{}, error) {
+	definition := make(map[string]interface{})
+	sources := make(map[string]string)
+
+	if raw.Type().HasAttribute("definition") {
+		if v := raw.GetAttr("definition"); v.IsKnown() && !v.IsNull() {
+			for it := v.ElementIterator(); it.Next(); {
+				key, val := it.Element()
+				if !val.IsKnown() || val.IsNull() {
+					continue
+				}
+				definition[key.AsString()] = val.AsString()
+				sources[key.AsString()] = "definition"
+			}
+		}
+	}
+
+	if raw.Type().HasAttribute("definition_json") {
+		if v := raw.GetAttr("definition_json"); v.IsKnown() && !v.IsNull() && v.AsString() != "" {
+			values := make(map[string]interface{})
+			decoder := json.NewDecoder(bytes.NewBufferString(v.AsString()))
+			decoder.UseNumber()
+			if err := decoder.Decode(&values); err != nil {
+				return nil, fmt.Errorf("unable to parse 'definition_json', a JSON object is expected: %v", err)
+			}
+			for key, val := range values {
+				definition[key] = val
+				sources[key] = "definition_json"
+			}
+		}
+	}
+
+	for _, a := range policyDefinitionAttributes {
+		if !raw.Type().HasAttribute(a.attribute) {
+			continue
+		}
+		v := raw.GetAttr(a.attribute)
+		if v.IsNull() {
+			continue
+		}
+
+		if source, ok := sources[a.key]; ok {
+			return nil, fmt.Errorf("the policy definition key '%s' is set by the `%s` attribute and by `%s`", a.key, a.attribute, source)
+		}
+		sources[a.key] = a.attribute
+
+		// the typed attributes are validated by their schema
+		if v.IsKnown() {
+			delete(definition, a.key)
+		}
+	}
+
+	return definition, nil
+}
+
+// check the type of a value set by the 'definition' map (strings) or by 'definition_json' (JSON values)
+func checkPolicyValue(key string, k policyKey, value interface{}) error {
+	switch k.valueType {
+	case policyValueInteger:
+		isInteger := false
+		switch v := value.(type) {
+		case string:
+			_, err := strconv.ParseInt(v, 10, 64)
+			isInteger = err == nil
+		case json.Number:
+			_, err := v.Int64()
+			isInteger = err == nil
+		}
+		if !isInteger {
+			return fmt.Errorf("the value of the key '%s' must be an integer, got: %v", key, value)
+		}
+
+	case policyValueString:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("the value of the key '%s' must be a string, got: %v", key, value)
+		}
+		if len(k.values) > 0 && !slices.Contains(k.values, s) {
+			return fmt.Errorf("the value of the key '%s' must be one of [%s], got: %s", key, strings.Join(k.values, ", "), s)
+		}
+	}
+
+	return nil
+}
+
+// validatePolicyDefinitionKeys warns about the keys which are not known by the provider, they may be ignored by RabbitMQ
+func validatePolicyDefinitionKeys(i interface{}, path cty.Path) diag.Diagnostics {
+	var keys []string
+
+	switch v := i.(type) {
+	case map[string]interface{}:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	case string:
+		values := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(v), &values); err != nil {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Invalid policy definition",
+				Detail:        fmt.Sprintf("a JSON object is expected: %v", err),
+				AttributePath: path,
+			}}
+		}
+		for key := range values {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var diags diag.Diagnostics
+	for _, key := range keys {
+		if _, ok := policyKeys[key]; !ok {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       "Unknown policy definition key",
+				Detail:        fmt.Sprintf("The key '%s' is not a known policy definition key, it may be ignored by RabbitMQ.", key),
+				AttributePath: path,
+			})
+		}
+	}
+
+	return diags
+}
+
+func operatorPolicyKeys() []string {
+	var keys []string
+	for key, k := range policyKeys {
+		if k.operator {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestCheckPolicyDefinition(t *testing.T) {
+	var tests = []struct {
+		definition map[string]interface{}
+		applyTo    string
+		operator   bool
+		valid      bool
+	}{
+		{map[string]interface{}{"max-length": "1000", "overflow": "reject-publish"}, "queues", false, true},
+		{map[string]interface{}{"max-length": json.Number("1000"), "ha-params": []interface{}{"a", "b"}}, "all", false, true},
+		{map[string]interface{}{"alternate-exchange": "ae"}, "exchanges", false, true},
+		{map[string]interface{}{"federation-upstream-set": "all"}, "streams", false, true},
+		{map[string]interface{}{"x-unknown": true}, "queues", false, true},
+		{map[string]interface{}{"max-length": "1000"}, "", false, true},
+		{map[string]interface{}{"max-length": "many"}, "queues", false, false},
+		{map[string]interface{}{"max-length": json.Number("1.5")}, "queues", false, false},
+		{map[string]interface{}{"dead-letter-exchange": json.Number("1")}, "queues", false, false},
+		{map[string]interface{}{"overflow": "drop-tail"}, "queues", false, false},
+		{map[string]interface{}{"max-length": "1000"}, "exchanges", false, false},
+		{map[string]interface{}{"alternate-exchange": "ae"}, "quorum_queues", false, false},
+		{map[string]interface{}{"max-length": "1000", "expires": "60000"}, "queues", true, true},
+		{map[string]interface{}{"dead-letter-exchange": "dlx"}, "queues", true, false},
+	}
+
+	for _, test := range tests {
+		err := checkPolicyDefinition(test.definition, test.applyTo, test.operator)
+		if (err == nil) != test.valid {
+			t.Errorf("checkPolicyDefinition failed for %#v (apply_to: %s, operator: %t): %v", test.definition, test.applyTo, test.operator, err)
+		}
+	}
+}
+
+func TestRawPolicyDefinition(t *testing.T) {
+	raw := cty.ObjectVal(map[string]cty.Value{
+		"definition":      cty.MapVal(map[string]cty.Value{"overflow": cty.StringVal("drop-head"), "expires": cty.UnknownVal(cty.String)}),
+		"definition_json": cty.NullVal(cty.String),
+		"max_length":      cty.NumberIntVal(100),
+		"message_ttl":     cty.UnknownVal(cty.Number),
+	})
+
+	definition, err := rawPolicyDefinition(raw)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(definition) != 1 || definition["overflow"] != "drop-head" {
+		t.Errorf("rawPolicyDefinition failed: %#v", definition)
+	}
+
+	raw = cty.ObjectVal(map[string]cty.Value{
+		"definition":      cty.NullVal(cty.Map(cty.String)),
+		"definition_json": cty.StringVal(`{"max-length": 10}`),
+		"max_length":      cty.NumberIntVal(100),
+	})
+
+	if _, err := rawPolicyDefinition(raw); err == nil {
+		t.Errorf("rawPolicyDefinition should fail when a key is set twice")
+	}
+}
+
+func TestValidatePolicyDefinitionKeys(t *testing.T) {
+	diags := validatePolicyDefinitionKeys(map[string]interface{}{"max-length": "10", "max-lenght": "10"}, cty.Path{})
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("validatePolicyDefinitionKeys should warn about the unknown key: %#v", diags)
+	}
+
+	diags = validatePolicyDefinitionKeys(`{"max-length": 10, "overflow": "drop-head"}`, cty.Path{})
+	if len(diags) != 0 {
+		t.Errorf("validatePolicyDefinitionKeys failed: %#v", diags)
+	}
+
+	diags = validatePolicyDefinitionKeys(`[1, 2]`, cty.Path{})
+	if !diags.HasError() {
+		t.Errorf("validatePolicyDefinitionKeys should fail for a JSON array")
+	}
+}
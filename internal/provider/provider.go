@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
@@ -8,6 +9,7 @@ import (
 	"net/url"
 	"os"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 )
@@ -124,15 +126,26 @@ func New() *schema.Provider {
 			"rabbitmq_exchange_topic":           resourceExchangeTopic(),
 			"rabbitmq_exchange_delayed_message": resourceExchangeDelayedMessage(),
 			"rabbitmq_exchange_random":          resourceExchangeRandom(),
+			"rabbitmq_exchange_local_random":    resourceExchangeLocalRandom(),
+			"rabbitmq_exchange_jms_topic":       resourceExchangeJmsTopic(),
+			"rabbitmq_exchange_recent_history":  resourceExchangeRecentHistory(),
+			"rabbitmq_exchange_modulus_hash":    resourceExchangeModulusHash(),
 			"rabbitmq_exchange_consistent_hash": resourceExchangeConsistentHash(),
 			"rabbitmq_permissions":              resourcePermissions(),
 			"rabbitmq_topic_permissions":        resourceTopicPermissions(),
+			"rabbitmq_federation":               resourceFederation(),
 			"rabbitmq_federation_upstream":      resourceFederationUpstream(),
+			"rabbitmq_federation_upstream_set":  resourceFederationUpstreamSet(),
+			"rabbitmq_global_parameter":         resourceGlobalParameter(),
 			"rabbitmq_operator_policy":          resourceOperatorPolicy(),
+			"rabbitmq_parameter":                resourceParameter(),
 			"rabbitmq_policy":                   resourcePolicy(),
 			"rabbitmq_queue":                    resourceQueue(),
 			"rabbitmq_user":                     resourceUser(),
+			"rabbitmq_user_limits":              resourceUserLimits(),
 			"rabbitmq_vhost":                    resourceVhost(),
+			"rabbitmq_vhost_limits":             resourceVhostLimits(),
+			"rabbitmq_vhost_permissions":        resourceVhostPermissions(),
 			"rabbitmq_shovel":                   resourceShovel(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
@@ -143,17 +156,26 @@ func New() *schema.Provider {
 			"rabbitmq_exchange_topic":           datasourceExchangeTopic(),
 			"rabbitmq_exchange_delayed_message": datasourceExchangeDelayedMessage(),
 			"rabbitmq_exchange_random":          datasourceExchangeRandom(),
+			"rabbitmq_exchange_local_random":    datasourceExchangeLocalRandom(),
+			"rabbitmq_exchange_jms_topic":       datasourceExchangeJmsTopic(),
+			"rabbitmq_exchange_recent_history":  datasourceExchangeRecentHistory(),
+			"rabbitmq_exchange_modulus_hash":    datasourceExchangeModulusHash(),
 			"rabbitmq_exchange_consistent_hash": datasourceExchangeConsistentHash(),
+			"rabbitmq_effective_policy":         dataSourcesEffectivePolicy(),
+			"rabbitmq_federation_links":         dataSourcesFederationLinks(),
+			"rabbitmq_global_parameter":         dataSourcesGlobalParameter(),
+			"rabbitmq_parameter":                dataSourcesParameter(),
+			"rabbitmq_policy_analysis":          dataSourcesPolicyAnalysis(),
 			"rabbitmq_queue":                    dataSourcesQueue(),
 			"rabbitmq_user":                     dataSourcesUser(),
 			"rabbitmq_vhost":                    dataSourcesVhost(),
 		},
 
-		ConfigureFunc: providerConfigure,
+		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 
 	var username = d.Get("username").(string)
 	var password = d.Get("password").(string)
@@ -173,7 +195,7 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	if cacertFile != "" {
 		caCert, err := os.ReadFile(cacertFile)
 		if err != nil {
-			return nil, err
+			return nil, diag.FromErr(err)
 		}
 
 		caCertPool := x509.NewCertPool()
@@ -183,7 +205,7 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	if clientcertFile != "" && clientkeyFile != "" {
 		clientPair, err := tls.LoadX509KeyPair(clientcertFile, clientkeyFile)
 		if err != nil {
-			return nil, err
+			return nil, diag.FromErr(err)
 		}
 		tlsConfig.Certificates = []tls.Certificate{clientPair}
 	}
@@ -196,7 +218,7 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		var err error
 		proxyURL, err = url.Parse(proxy)
 		if err != nil {
-			return nil, fmt.Errorf("invalid proxy URL %q: %w", proxy, err)
+			return nil, diag.Errorf("invalid proxy URL %q: %s", proxy, err)
 		}
 	}
 
@@ -223,8 +245,13 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 
 	rmqc, err := rabbithole.NewTLSClient(endpoint, username, password, customTransport)
 	if err != nil {
-		return nil, err
+		return nil, diag.FromErr(err)
 	}
+	m := &Meta{Client: rmqc, transport: customTransport}
 
-	return rmqc, nil
+	// The version of the broker is used to report the deprecated and removed features, its exchange types and
+	// enabled plugins to fail at plan the resources depending on a missing plugin
+	m.broker = fetchBrokerInfo(m)
+
+	return m, usedDeprecatedFeatures(m, m.broker.version)
 }
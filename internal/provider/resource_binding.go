@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -13,6 +14,9 @@ import (
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 )
 
+// The binding argument of the selector of the messages routed by an exchange of type 'x-jms-topic'
+const jmsTopicSelectorArgument = "x-jms-topic-selector"
+
 func resourceBinding() *schema.Resource {
 	return &schema.Resource{
 		Description: "The `rabbitmq_binding` resource creates and manages a binding relationship between a queue an exchange.",
@@ -22,10 +26,11 @@ func resourceBinding() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customizeBindingDiff,
 
 		Schema: map[string]*schema.Schema{
 			"source": {
-				Description: "The source exchange.",
+				Description: "The source exchange.\n-> **Note:** The binding is checked against the type of the source at plan, e.g. an exchange of type `x-local-random` rejects the routing keys. When the source is declared in the same apply, the binding is checked when it's created, the apply fails after the source is declared.",
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
@@ -65,6 +70,13 @@ func resourceBinding() *schema.Resource {
 				ForceNew:    true,
 			},
 
+			"jms_topic_selector": {
+				Description: "The selector of the messages routed to the destination, when the source is an exchange of type `x-jms-topic`. It's set as the argument `x-jms-topic-selector` of the binding.\n-> **Note:** A selector set by `arguments` or `arguments_json` is kept there, the binding isn't replaced to manage it with this attribute.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+
 			"arguments": {
 				Description:   "Additional key/value arguments for the binding.\n~> **Note:** Either this or `arguments` must be specified but not both.",
 				Type:          schema.TypeMap,
@@ -85,8 +97,46 @@ func resourceBinding() *schema.Resource {
 	}
 }
 
+// reject the bindings an existing source exchange doesn't support. The type of the source is only known from the
+// broker, the configuration of the exchange resource isn't visible from the binding, so the check is skipped when the
+// source isn't declared yet and done again by CreateBinding. The exchange is retrieved only when the binding is
+// created or replaced.
+func customizeBindingDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	m, ok := meta.(*Meta)
+	if !ok || !diff.NewValueKnown("source") || !diff.NewValueKnown("vhost") || diff.Get("source").(string) == "" {
+		return nil
+	}
+	if diff.Id() != "" && !diff.HasChanges("source", "vhost", "destination_type", "routing_key", "jms_topic_selector") {
+		return nil
+	}
+
+	// the source exchange may not be declared yet
+	exchange, err := m.Client.GetExchange(diff.Get("vhost").(string), diff.Get("source").(string))
+	if err != nil {
+		return nil
+	}
+
+	return checkBindingSource(exchange.Type, diff.Get("destination_type").(string), diff.Get("routing_key").(string), diff.Get("jms_topic_selector").(string))
+}
+
+func checkBindingSource(exchangeType string, destinationType string, routingKey string, jmsTopicSelector string) error {
+	if jmsTopicSelector != "" && exchangeType != "x-jms-topic" {
+		return fmt.Errorf("'jms_topic_selector' is only supported by the exchanges of type 'x-jms-topic', the source is of type '%s'", exchangeType)
+	}
+
+	if exchangeType == "x-local-random" {
+		if destinationType == "exchange" {
+			return fmt.Errorf("an exchange of type 'x-local-random' can only be bound to queues")
+		}
+		if routingKey != "" {
+			return fmt.Errorf("an exchange of type 'x-local-random' ignores the routing key of its bindings, 'routing_key' must not be set")
+		}
+	}
+	return nil
+}
+
 func CreateBinding(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	vhost := d.Get("vhost").(string)
 	arguments := d.Get("arguments").(map[string]interface{})
@@ -103,6 +153,25 @@ func CreateBinding(d *schema.ResourceData, meta interface{}) error {
 		arguments = arguments_json
 	}
 
+	if selector := d.Get("jms_topic_selector").(string); selector != "" {
+		withSelector := map[string]interface{}{jmsTopicSelectorArgument: selector}
+		for key, value := range arguments {
+			if key != jmsTopicSelectorArgument {
+				withSelector[key] = value
+			}
+		}
+		arguments = withSelector
+	}
+
+	// the source may be declared in the same apply, after the plan of the binding
+	if source := d.Get("source").(string); source != "" {
+		if exchange, err := rmqc.GetExchange(vhost, source); err == nil {
+			if err := checkBindingSource(exchange.Type, d.Get("destination_type").(string), d.Get("routing_key").(string), d.Get("jms_topic_selector").(string)); err != nil {
+				return err
+			}
+		}
+	}
+
 	bindingInfo := rabbithole.BindingInfo{
 		Source:          d.Get("source").(string),
 		Destination:     d.Get("destination").(string),
@@ -125,7 +194,7 @@ func CreateBinding(d *schema.ResourceData, meta interface{}) error {
 }
 
 func ReadBinding(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	log.Printf("[TRACE] RabbitMQ: read binding resource ID (pre-split): %s", d.Id())
 	bindingId := strings.Split(d.Id(), "/")
@@ -177,14 +246,8 @@ func ReadBinding(d *schema.ResourceData, meta interface{}) error {
 			d.Set("routing_key", binding.RoutingKey)
 			d.Set("properties_key", binding.PropertiesKey)
 
-			if v, ok := d.Get("arguments_json").(string); ok && v != "" {
-				bytes, err := json.Marshal(binding.Arguments)
-				if err != nil {
-					return fmt.Errorf("could not encode arguments as JSON: %w", err)
-				}
-				d.Set("arguments_json", string(bytes))
-			} else {
-				d.Set("arguments", binding.Arguments)
+			if err := setBindingArguments(d, binding.Arguments); err != nil {
+				return err
 			}
 		}
 	}
@@ -198,8 +261,36 @@ func ReadBinding(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// set the arguments of the binding read from the server. The selector is only moved out of the arguments into
+// 'jms_topic_selector' when this attribute is used, a selector set by 'arguments' or 'arguments_json' stays in the
+// arguments, so the bindings declared before 'jms_topic_selector' existed aren't replaced.
+func setBindingArguments(d *schema.ResourceData, serverArguments map[string]interface{}) error {
+	arguments := make(map[string]interface{}, len(serverArguments))
+	for key, value := range serverArguments {
+		arguments[key] = value
+	}
+
+	if d.Get("jms_topic_selector").(string) != "" {
+		selector, _ := arguments[jmsTopicSelectorArgument].(string)
+		delete(arguments, jmsTopicSelectorArgument)
+		d.Set("jms_topic_selector", selector)
+	}
+
+	if v, ok := d.Get("arguments_json").(string); ok && v != "" {
+		bytes, err := json.Marshal(arguments)
+		if err != nil {
+			return fmt.Errorf("could not encode arguments as JSON: %w", err)
+		}
+		d.Set("arguments_json", string(bytes))
+	} else {
+		d.Set("arguments", arguments)
+	}
+
+	return nil
+}
+
 func DeleteBinding(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	bindingId := strings.Split(d.Id(), "/")
 	if len(bindingId) < 5 {
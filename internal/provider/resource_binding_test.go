@@ -12,6 +12,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider"
 )
 
 func TestAccBinding_basic(t *testing.T) {
@@ -102,7 +103,7 @@ func testAccBindingCheck(rn string, bindingInfo *rabbithole.BindingInfo) resourc
 			return fmt.Errorf("binding id not set")
 		}
 
-		rmqc := acceptance.TestAcc.Provider.Meta().(*rabbithole.Client)
+		rmqc := acceptance.TestAcc.Provider.Meta().(*provider.Meta).Client
 		bindingParts := strings.Split(rs.Primary.ID, "/")
 
 		bindings, err := rmqc.ListBindingsIn(strings.ReplaceAll(strings.ReplaceAll(bindingParts[0], "%2F", "/"), "%25", "%"))
@@ -149,7 +150,7 @@ func testAccBindingCheckJsonArguments(rn string, bindingInfo *rabbithole.Binding
 
 func testAccBindingCheckDestroy(bindingInfo rabbithole.BindingInfo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := acceptance.TestAcc.Provider.Meta().(*rabbithole.Client)
+		rmqc := acceptance.TestAcc.Provider.Meta().(*provider.Meta).Client
 
 		bindings, err := rmqc.ListBindingsIn(bindingInfo.Vhost)
 		if err != nil {
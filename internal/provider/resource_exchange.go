@@ -97,7 +97,7 @@ func resourceExchange() *schema.Resource {
 }
 
 func CreateExchange(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name := d.Get("name").(string)
 	vhost := d.Get("vhost").(string)
@@ -120,7 +120,7 @@ func CreateExchange(d *schema.ResourceData, meta interface{}) error {
 }
 
 func ReadExchange(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -153,7 +153,7 @@ func ReadExchange(d *schema.ResourceData, meta interface{}) error {
 }
 
 func DeleteExchange(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -1,7 +1,6 @@
 package provider
 
 import (
-	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/core/resources"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -28,7 +27,8 @@ func resourceExchangeDelayedMessage() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: mySchema,
+		CustomizeDiff: customizeExchangePluginDiff("x-delayed-message", pluginDelayedMessageExchange),
+		Schema:        mySchema,
 	}
 }
 
@@ -41,11 +41,18 @@ func CreateExchangeDelayedMessage(d *schema.ResourceData, meta interface{}) erro
 	args.Add(map[string]interface{}{"key": "x-delayed-type", "value": d.Get("delayed_type").(string), "type": "string"})
 	d.Set("argument", args)
 
-	return resources.CreateExchange(d, meta.(*rabbithole.Client))
+	return resources.CreateExchange(d, meta.(*Meta).Client)
 }
 
 func ReadExchangeDelayedMessage(d *schema.ResourceData, meta interface{}) error {
-	if err := resources.ReadExchange(d, meta.(*rabbithole.Client)); err != nil {
+	// The specific argument is read as a configured one, except on import where all the arguments are read
+	if delayedType := d.Get("delayed_type").(string); delayedType != "" {
+		args := d.Get("argument").(*schema.Set)
+		args.Add(map[string]interface{}{"key": "x-delayed-type", "value": delayedType, "type": "string"})
+		d.Set("argument", args)
+	}
+
+	if err := resources.ReadExchange(d, meta.(*Meta).Client); err != nil {
 		return err
 	}
 
@@ -65,5 +72,5 @@ func ReadExchangeDelayedMessage(d *schema.ResourceData, meta interface{}) error
 }
 
 func DeleteExchangeDelayedMessage(d *schema.ResourceData, meta interface{}) error {
-	return resources.DeleteExchange(d, meta.(*rabbithole.Client))
+	return resources.DeleteExchange(d, meta.(*Meta).Client)
 }
@@ -240,6 +240,38 @@ func TestAccExchangeDirect_ArgumentsBoolean(t *testing.T) {
 	})
 }
 
+func TestAccExchangeDirect_ArgumentsList(t *testing.T) {
+	data := acceptance_test.BuildTestData("rabbitmq_exchange_direct", "test")
+	r := acceptance_test.ExchangeDirectResource{
+		ExchangeResource: acceptance_test.ExchangeResource{
+			Name:    data.RandomString(),
+			Vhost:   "/",
+			Type:    "direct",
+			Durable: true,
+			Arguments: []map[string]interface{}{
+				{"key": data.RandomString(), "value": "myString, 12345, true", "type": "list"},
+			}}}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance_test.TestAcc.PreCheck(t) },
+		Providers:    acceptance_test.TestAcc.Providers,
+		CheckDestroy: r.CheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: r.OptionalArgumentsString(data),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance_test.That(data.ResourceName).Exists(),
+					acceptance_test.That(data.ResourceName).Key("argument.#").Exists(),
+					acceptance_test.That(data.ResourceName).Key("argument.0.key").HasValue(r.Arguments[0]["key"].(string)),
+					acceptance_test.That(data.ResourceName).Key("argument.0.value").HasValue(r.Arguments[0]["value"].(string)),
+					acceptance_test.That(data.ResourceName).Key("argument.0.type").HasValue(r.Arguments[0]["type"].(string)),
+					r.ExistsInRabbitMQ(),
+				),
+			},
+		},
+	})
+}
+
 func TestAccExchangeDirect_ArgumentTypeValidation(t *testing.T) {
 	data := acceptance_test.BuildTestData("rabbitmq_exchange_direct", "test")
 	r := acceptance_test.ExchangeDirectResource{
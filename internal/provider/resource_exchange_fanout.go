@@ -1,7 +1,6 @@
 package provider
 
 import (
-	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/core/resources"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -24,13 +23,13 @@ func CreateExchangeFanout(d *schema.ResourceData, meta interface{}) error {
 	// Set the exchange type
 	d.Set("type", "fanout")
 
-	return resources.CreateExchange(d, meta.(*rabbithole.Client))
+	return resources.CreateExchange(d, meta.(*Meta).Client)
 }
 
 func ReadExchangeFanout(d *schema.ResourceData, meta interface{}) error {
-	return resources.ReadExchange(d, meta.(*rabbithole.Client))
+	return resources.ReadExchange(d, meta.(*Meta).Client)
 }
 
 func DeleteExchangeFanout(d *schema.ResourceData, meta interface{}) error {
-	return resources.DeleteExchange(d, meta.(*rabbithole.Client))
+	return resources.DeleteExchange(d, meta.(*Meta).Client)
 }
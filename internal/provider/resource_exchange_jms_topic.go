@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/core/resources"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceExchangeJmsTopic() *schema.Resource {
+	return &schema.Resource{
+		Description: "Exchange --- The `rabbitmq_exchange_jms_topic` resource creates and manages an _exchange_ of type 'x-jms-topic'.",
+		Create:      CreateExchangeJmsTopic,
+		Read:        ReadExchangeJmsTopic,
+		Delete:      DeleteExchangeJmsTopic,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customizeExchangePluginDiff("x-jms-topic", pluginJmsTopicExchange),
+		Schema:        resources.Exchange(),
+	}
+}
+
+func CreateExchangeJmsTopic(d *schema.ResourceData, meta interface{}) error {
+	// Set the exchange type
+	d.Set("type", "x-jms-topic")
+
+	return resources.CreateExchange(d, meta.(*Meta).Client)
+}
+
+func ReadExchangeJmsTopic(d *schema.ResourceData, meta interface{}) error {
+	return resources.ReadExchange(d, meta.(*Meta).Client)
+}
+
+func DeleteExchangeJmsTopic(d *schema.ResourceData, meta interface{}) error {
+	return resources.DeleteExchange(d, meta.(*Meta).Client)
+}
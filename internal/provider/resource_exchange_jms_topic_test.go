@@ -0,0 +1,65 @@
+package provider_test
+
+import (
+	"testing"
+
+	acceptance_test "github.com/rfd59/terraform-provider-rabbitmq/test/acceptance"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccExchangeJmsTopic_Required(t *testing.T) {
+	data := acceptance_test.BuildTestData("rabbitmq_exchange_jms_topic", "test")
+	r := acceptance_test.ExchangeJmsTopicResource{
+		ExchangeResource: acceptance_test.ExchangeResource{
+			Name:    data.RandomString(),
+			Vhost:   "/",
+			Type:    "x-jms-topic",
+			Durable: true}}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance_test.TestAcc.PreCheck(t) },
+		Providers:    acceptance_test.TestAcc.Providers,
+		CheckDestroy: r.CheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: r.RequiredCreate(data),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance_test.That(data.ResourceName).Exists(),
+					acceptance_test.That(data.ResourceName).Key("id").HasValue(r.Name+"@"+r.Vhost),
+					acceptance_test.That(data.ResourceName).Key("name").HasValue(r.Name),
+					acceptance_test.That(data.ResourceName).Key("vhost").HasValue(r.Vhost),
+					acceptance_test.That(data.ResourceName).Key("type").HasValue(r.Type),
+					acceptance_test.That(data.ResourceName).Key("durable").IsBool(r.Durable),
+					acceptance_test.That(data.ResourceName).Key("argument.#").DoesNotExist(),
+					r.ExistsInRabbitMQ(),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExchangeJmsTopic_BindingSelector(t *testing.T) {
+	data := acceptance_test.BuildTestData("rabbitmq_exchange_jms_topic", "test")
+	r := acceptance_test.ExchangeJmsTopicResource{
+		ExchangeResource: acceptance_test.ExchangeResource{
+			Name:  data.RandomString(),
+			Vhost: "/",
+		}}
+	selector := `{'=', {'ident', <<"type">>}, <<"order">>}`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance_test.TestAcc.PreCheck(t) },
+		Providers:    acceptance_test.TestAcc.Providers,
+		CheckDestroy: r.CheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: r.BindingSelector(data, selector),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance_test.That("rabbitmq_binding.test").Key("jms_topic_selector").HasValue(selector),
+					acceptance_test.That("rabbitmq_binding.test").Key("arguments.%").DoesNotExist(),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/core/resources"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The exchange type 'x-local-random' is built in RabbitMQ since 4.0
+const exchangeLocalRandomVersion = "4.0"
+
+func resourceExchangeLocalRandom() *schema.Resource {
+	return &schema.Resource{
+		Description: "Exchange --- The `rabbitmq_exchange_local_random` resource creates and manages an _exchange_ of type 'x-local-random'.",
+		Create:      CreateExchangeLocalRandom,
+		Read:        ReadExchangeLocalRandom,
+		Delete:      DeleteExchangeLocalRandom,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customizeExchangeLocalRandomDiff,
+		Schema:        resources.Exchange(),
+	}
+}
+
+func CreateExchangeLocalRandom(d *schema.ResourceData, meta interface{}) error {
+	// Set the exchange type
+	d.Set("type", "x-local-random")
+
+	return resources.CreateExchange(d, meta.(*Meta).Client)
+}
+
+func ReadExchangeLocalRandom(d *schema.ResourceData, meta interface{}) error {
+	return resources.ReadExchange(d, meta.(*Meta).Client)
+}
+
+func DeleteExchangeLocalRandom(d *schema.ResourceData, meta interface{}) error {
+	return resources.DeleteExchange(d, meta.(*Meta).Client)
+}
+
+// reject the brokers which don't know the exchange type and the settings it doesn't support
+func customizeExchangeLocalRandomDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	// an internal exchange is only published to by exchange to exchange bindings, which aren't supported
+	if diff.Get("internal").(bool) {
+		return fmt.Errorf("an exchange of type 'x-local-random' can't be internal: it can only be bound to queues")
+	}
+
+	if m, ok := meta.(*Meta); ok {
+		return checkExchangeLocalRandomVersion(m.brokerInfo().version)
+	}
+
+	return nil
+}
+
+// the check is skipped when the version of the broker is unknown
+func checkExchangeLocalRandomVersion(version string) error {
+	if version != "" && !isVersionAtLeast(version, exchangeLocalRandomVersion) {
+		return fmt.Errorf("the exchange type 'x-local-random' requires RabbitMQ %s or later, the broker runs RabbitMQ %s", exchangeLocalRandomVersion, version)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	acceptance_test "github.com/rfd59/terraform-provider-rabbitmq/test/acceptance"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAccExchangeLocalRandomPreCheck(t *testing.T) {
+	acceptance_test.TestAcc.PreCheck(t)
+	if !acceptance_test.TestAcc.ValidFeature("4.0") {
+		t.Skip("the exchange type 'x-local-random' needs RabbitMQ 4.0 or later")
+	}
+}
+
+func TestAccExchangeLocalRandom_Required(t *testing.T) {
+	data := acceptance_test.BuildTestData("rabbitmq_exchange_local_random", "test")
+	r := acceptance_test.ExchangeLocalRandomResource{
+		ExchangeResource: acceptance_test.ExchangeResource{
+			Name:    data.RandomString(),
+			Vhost:   "/",
+			Type:    "x-local-random",
+			Durable: true}}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccExchangeLocalRandomPreCheck(t) },
+		Providers:    acceptance_test.TestAcc.Providers,
+		CheckDestroy: r.CheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: r.RequiredCreate(data),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance_test.That(data.ResourceName).Exists(),
+					acceptance_test.That(data.ResourceName).Key("id").HasValue(r.Name+"@"+r.Vhost),
+					acceptance_test.That(data.ResourceName).Key("name").HasValue(r.Name),
+					acceptance_test.That(data.ResourceName).Key("vhost").HasValue(r.Vhost),
+					acceptance_test.That(data.ResourceName).Key("type").HasValue(r.Type),
+					acceptance_test.That(data.ResourceName).Key("durable").IsBool(r.Durable),
+					acceptance_test.That(data.ResourceName).Key("internal").IsBool(r.Internal),
+					acceptance_test.That(data.ResourceName).Key("argument.#").DoesNotExist(),
+					r.ExistsInRabbitMQ(),
+				),
+			},
+		},
+	})
+}
+
+func TestAccExchangeLocalRandom_Internal(t *testing.T) {
+	data := acceptance_test.BuildTestData("rabbitmq_exchange_local_random", "test")
+	r := acceptance_test.ExchangeLocalRandomResource{
+		ExchangeResource: acceptance_test.ExchangeResource{
+			Name: data.RandomString(),
+		}}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccExchangeLocalRandomPreCheck(t) },
+		Providers: acceptance_test.TestAcc.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      r.ErrorInternal(data),
+				ExpectError: regexp.MustCompile("can't be internal"),
+			},
+		},
+	})
+}
+
+func TestAccExchangeLocalRandom_BindingRoutingKey(t *testing.T) {
+	data := acceptance_test.BuildTestData("rabbitmq_exchange_local_random", "test")
+	r := acceptance_test.ExchangeLocalRandomResource{
+		ExchangeResource: acceptance_test.ExchangeResource{
+			Name:  data.RandomString(),
+			Vhost: "/",
+		}}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccExchangeLocalRandomPreCheck(t) },
+		Providers:    acceptance_test.TestAcc.Providers,
+		CheckDestroy: r.CheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: r.RequiredCreate(data),
+			},
+			{
+				// the binding is checked once the exchange is declared
+				Config:      r.ErrorBindingRoutingKey(data),
+				ExpectError: regexp.MustCompile("ignores the routing key"),
+			},
+		},
+	})
+}
+
+func TestAccExchangeLocalRandom_BindingRoutingKeySameApply(t *testing.T) {
+	data := acceptance_test.BuildTestData("rabbitmq_exchange_local_random", "test")
+	r := acceptance_test.ExchangeLocalRandomResource{
+		ExchangeResource: acceptance_test.ExchangeResource{
+			Name:  data.RandomString(),
+			Vhost: "/",
+		}}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccExchangeLocalRandomPreCheck(t) },
+		Providers:    acceptance_test.TestAcc.Providers,
+		CheckDestroy: r.CheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				// the exchange isn't declared at plan, the binding is checked when it's created
+				Config:      r.ErrorBindingRoutingKey(data),
+				ExpectError: regexp.MustCompile("ignores the routing key"),
+			},
+		},
+	})
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/core/resources"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceExchangeModulusHash() *schema.Resource {
+	return &schema.Resource{
+		Description: "Exchange --- The `rabbitmq_exchange_modulus_hash` resource creates and manages an _exchange_ of type 'x-modulus-hash'.",
+		Create:      CreateExchangeModulusHash,
+		Read:        ReadExchangeModulusHash,
+		Delete:      DeleteExchangeModulusHash,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customizeExchangePluginDiff("x-modulus-hash", pluginSharding),
+		Schema:        resources.Exchange(),
+	}
+}
+
+func CreateExchangeModulusHash(d *schema.ResourceData, meta interface{}) error {
+	// Set the exchange type
+	d.Set("type", "x-modulus-hash")
+
+	return resources.CreateExchange(d, meta.(*Meta).Client)
+}
+
+func ReadExchangeModulusHash(d *schema.ResourceData, meta interface{}) error {
+	return resources.ReadExchange(d, meta.(*Meta).Client)
+}
+
+func DeleteExchangeModulusHash(d *schema.ResourceData, meta interface{}) error {
+	return resources.DeleteExchange(d, meta.(*Meta).Client)
+}
@@ -1,7 +1,6 @@
 package provider
 
 import (
-	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/core/resources"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -16,7 +15,8 @@ func resourceExchangeRandom() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: resources.Exchange(),
+		CustomizeDiff: customizeExchangePluginDiff("x-random", pluginRandomExchange),
+		Schema:        resources.Exchange(),
 	}
 }
 
@@ -24,13 +24,13 @@ func CreateExchangeRandom(d *schema.ResourceData, meta interface{}) error {
 	// Set the exchange type
 	d.Set("type", "x-random")
 
-	return resources.CreateExchange(d, meta.(*rabbithole.Client))
+	return resources.CreateExchange(d, meta.(*Meta).Client)
 }
 
 func ReadExchangeRandom(d *schema.ResourceData, meta interface{}) error {
-	return resources.ReadExchange(d, meta.(*rabbithole.Client))
+	return resources.ReadExchange(d, meta.(*Meta).Client)
 }
 
 func DeleteExchangeRandom(d *schema.ResourceData, meta interface{}) error {
-	return resources.DeleteExchange(d, meta.(*rabbithole.Client))
+	return resources.DeleteExchange(d, meta.(*Meta).Client)
 }
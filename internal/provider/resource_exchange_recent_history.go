@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"strconv"
+
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/core/resources"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceExchangeRecentHistory() *schema.Resource {
+	// Load and customize the resource schema
+	mySchema := resources.Exchange()
+	mySchema["history_length"] = &schema.Schema{
+		Description:  "The number of the latest messages kept by the exchange and delivered to the queues bound to it. Defaults to `20` on the broker.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+	}
+
+	return &schema.Resource{
+		Description: "Exchange --- The `rabbitmq_exchange_recent_history` resource creates and manages an _exchange_ of type 'x-recent-history'.",
+		Create:      CreateExchangeRecentHistory,
+		Read:        ReadExchangeRecentHistory,
+		Delete:      DeleteExchangeRecentHistory,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customizeExchangePluginDiff("x-recent-history", pluginRecentHistoryExchange),
+		Schema:        mySchema,
+	}
+}
+
+func CreateExchangeRecentHistory(d *schema.ResourceData, meta interface{}) error {
+	// Set the exchange type
+	d.Set("type", "x-recent-history")
+
+	// Add specific argument
+	if length := d.Get("history_length").(int); length > 0 {
+		args := d.Get("argument").(*schema.Set)
+		args.Add(map[string]interface{}{"key": "x-recent-history-length", "value": strconv.Itoa(length), "type": "numeric"})
+		d.Set("argument", args)
+	}
+
+	return resources.CreateExchange(d, meta.(*Meta).Client)
+}
+
+func ReadExchangeRecentHistory(d *schema.ResourceData, meta interface{}) error {
+	// The specific argument is read as a configured one, except on import where all the arguments are read
+	if length := d.Get("history_length").(int); length > 0 {
+		args := d.Get("argument").(*schema.Set)
+		args.Add(map[string]interface{}{"key": "x-recent-history-length", "value": strconv.Itoa(length), "type": "numeric"})
+		d.Set("argument", args)
+	}
+
+	if err := resources.ReadExchange(d, meta.(*Meta).Client); err != nil {
+		return err
+	}
+
+	// Add specific argument
+	d.Set("history_length", popHistoryLength(d))
+
+	return nil
+}
+
+func DeleteExchangeRecentHistory(d *schema.ResourceData, meta interface{}) error {
+	return resources.DeleteExchange(d, meta.(*Meta).Client)
+}
+
+// remove the argument 'x-recent-history-length' from the argument set, 0 when it isn't set
+func popHistoryLength(d *schema.ResourceData) int {
+	length := 0
+
+	args := d.Get("argument").(*schema.Set)
+	for _, v := range args.List() {
+		arg := v.(map[string]interface{})
+		if arg["key"].(string) == "x-recent-history-length" {
+			if value, err := strconv.ParseFloat(arg["value"].(string), 64); err == nil {
+				length = int(value)
+			}
+			args.Remove(arg)
+			break
+		}
+	}
+	d.Set("argument", args)
+
+	return length
+}
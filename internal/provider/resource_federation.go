@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/utils"
+)
+
+func resourceFederation() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The `rabbitmq_federation` resource creates and manages the policy which federates the exchanges or the queues matching a pattern from a federation upstream or a federation upstream set.\n~> **Note:** The policy can't be managed by this resource and by a `rabbitmq_policy` resource at the same time.",
+		CreateContext: CreateFederation,
+		Read:          ReadFederation,
+		UpdateContext: UpdateFederation,
+		Delete:        DeleteFederation,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customizePluginDiff(pluginFederation),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Minute),
+			Update: schema.DefaultTimeout(2 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The name of the policy.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"vhost": {
+				Description: "The vhost to create the resource in.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"pattern": {
+				Description:  "A pattern to match the names of the federated exchanges or queues.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: utils.ValidateErlangRegex,
+			},
+
+			"apply_to": {
+				Description:  "Can be `exchanges`, `queues` or `all`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"exchanges", "queues", "all"}, false),
+			},
+
+			"priority": {
+				Description: "The priority of the policy, the policy with the greater priority is applied. Defaults to `0`.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+			},
+
+			"upstream": {
+				Description:  "The name of the federation upstream.\n~> **Note:** Either this or `upstream_set` must be specified but not both.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"upstream", "upstream_set"},
+			},
+
+			"upstream_set": {
+				Description:  "The name of the federation upstream set, `all` to use all the federation upstreams of the vhost.\n~> **Note:** Either this or `upstream` must be specified but not both.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"upstream", "upstream_set"},
+			},
+
+			"wait_for_links_running": {
+				Description: "Wait until the federation links of the upstreams are running when the policy is created or updated, the apply fails with the error of a link. The links are created for the exchanges or the queues matching the pattern, the wait lasts until the timeout when nothing matches it. The wait is limited by the `create` and `update` timeouts. Defaults to `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	}
+}
+
+func CreateFederation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*Meta).Client
+
+	name := d.Get("name").(string)
+	vhost := d.Get("vhost").(string)
+
+	if err := putFederation(rmqc, d, vhost, name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s@%s", name, vhost))
+
+	if d.Get("wait_for_links_running").(bool) {
+		if err := waitForFederationPolicyLinks(ctx, rmqc, d, vhost, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diag.FromErr(ReadFederation(d, meta))
+}
+
+func ReadFederation(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	name, vhost, err := parseResourceId(d)
+	if err != nil {
+		return err
+	}
+
+	policy, err := rmqc.GetPolicy(vhost, name)
+	if err != nil {
+		return checkDeleted(d, err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Federation policy retrieved for %s: %#v", d.Id(), policy)
+
+	upstream, _ := policy.Definition["federation-upstream"].(string)
+	upstreamSet, _ := policy.Definition["federation-upstream-set"].(string)
+
+	d.Set("name", policy.Name)
+	d.Set("vhost", policy.Vhost)
+	d.Set("pattern", policy.Pattern)
+	d.Set("apply_to", policy.ApplyTo)
+	d.Set("priority", policy.Priority)
+	d.Set("upstream", upstream)
+	d.Set("upstream_set", upstreamSet)
+	// not stored by RabbitMQ, keep the configured value or the default on import
+	d.Set("wait_for_links_running", d.Get("wait_for_links_running").(bool))
+
+	return nil
+}
+
+func UpdateFederation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*Meta).Client
+
+	name, vhost, err := parseResourceId(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("pattern", "apply_to", "priority", "upstream", "upstream_set") {
+		if err := putFederation(rmqc, d, vhost, name); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if d.Get("wait_for_links_running").(bool) {
+			if err := waitForFederationPolicyLinks(ctx, rmqc, d, vhost, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return diag.FromErr(ReadFederation(d, meta))
+}
+
+func DeleteFederation(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	name, vhost, err := parseResourceId(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to delete federation policy %s", d.Id())
+
+	resp, err := rmqc.DeletePolicy(vhost, name)
+	if err != nil || (resp.StatusCode >= 400 && resp.StatusCode != 404) {
+		return failApiResponse(err, resp, "deleting", "federation policy")
+	}
+
+	return nil
+}
+
+func putFederation(rmqc *rabbithole.Client, d *schema.ResourceData, vhost string, name string) error {
+	definition := rabbithole.PolicyDefinition{}
+	if v := d.Get("upstream").(string); v != "" {
+		definition["federation-upstream"] = v
+	} else {
+		definition["federation-upstream-set"] = d.Get("upstream_set").(string)
+	}
+
+	policy := rabbithole.Policy{
+		Vhost:      vhost,
+		Name:       name,
+		Pattern:    d.Get("pattern").(string),
+		ApplyTo:    d.Get("apply_to").(string),
+		Priority:   d.Get("priority").(int),
+		Definition: definition,
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to declare federation policy for %s@%s: %#v", name, vhost, policy)
+
+	resp, err := rmqc.PutPolicy(vhost, name, policy)
+	if err != nil || resp.StatusCode >= 400 {
+		return failApiResponse(err, resp, "declaring", "federation policy")
+	}
+
+	return nil
+}
+
+// wait until the links of the upstreams used by the policy are running, the upstreams of a set are resolved
+func waitForFederationPolicyLinks(ctx context.Context, rmqc *rabbithole.Client, d *schema.ResourceData, vhost string, timeout time.Duration) error {
+	var upstreams []string
+
+	switch set := d.Get("upstream_set").(string); set {
+	case "":
+		upstreams = []string{d.Get("upstream").(string)}
+	case "all":
+		all, err := rmqc.ListFederationUpstreamsIn(vhost)
+		if err != nil {
+			return fmt.Errorf("error retrieving the federation upstreams of vhost '%s': %v", vhost, err)
+		}
+		for _, u := range all {
+			upstreams = append(upstreams, u.Name)
+		}
+	default:
+		parameter, err := rmqc.GetRuntimeParameter(federationUpstreamSetComponent, vhost, set)
+		if err != nil {
+			return fmt.Errorf("error retrieving the federation upstream set '%s@%s': %v", set, vhost, err)
+		}
+		upstreams = upstreamSetMembers(parameter.Value)
+	}
+
+	return waitForFederationLinks(ctx, rmqc, vhost, upstreams, timeout)
+}
@@ -1,9 +1,13 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
@@ -11,14 +15,19 @@ import (
 
 func resourceFederationUpstream() *schema.Resource {
 	return &schema.Resource{
-		Description: "The `rabbitmq_federation_upstream` resource creates and manages a federation upstream parameter.",
-		Create:      CreateFederationUpstream,
-		Read:        ReadFederationUpstream,
-		Update:      UpdateFederationUpstream,
-		Delete:      DeleteFederationUpstream,
+		Description:   "The `rabbitmq_federation_upstream` resource creates and manages a federation upstream parameter.",
+		CreateContext: CreateFederationUpstream,
+		Read:          ReadFederationUpstream,
+		UpdateContext: UpdateFederationUpstream,
+		Delete:        DeleteFederationUpstream,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customizePluginDiff(pluginFederation),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Minute),
+			Update: schema.DefaultTimeout(2 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -35,6 +44,13 @@ func resourceFederationUpstream() *schema.Resource {
 				ForceNew:    true,
 			},
 
+			"wait_for_links_running": {
+				Description: "Wait until the federation links of the upstream are running when the upstream is created or updated, the apply fails with the error of a link. The links are created by the policies referencing the upstream, only the policies which already reference it are waited for. The wait is limited by the `create` and `update` timeouts. Defaults to `false`.\n-> **Note:** When the policy is declared in the same apply as the upstream, use `wait_for_links_running` of the `rabbitmq_federation` resource.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
 			// "federation-upstream"
 			"component": {
 				Description: "Set to _federation-upstream_ by the underlying RabbitMQ provider. You do not set this attribute but will see it in state and plan output.",
@@ -51,12 +67,31 @@ func resourceFederationUpstream() *schema.Resource {
 					Schema: map[string]*schema.Schema{
 						// applicable to both federated exchanges and queues
 						"uri": {
-							Description: "The AMQP Uri for the upstream.\n~> **Note:** The Uri may contain sensitive information, such as a password.",
-							Type:        schema.TypeString,
-							Required:    true,
+							Description:  "The AMQP Uri for the upstream.\n~> **Note:** The Uri may contain sensitive information, such as a password, the password is redacted in the state when it isn't the configured one, e.g. after an import.\n~> **Note:** Exactly one of `uri`, `uris` or `connection` must be specified.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							ExactlyOneOf: []string{"definition.0.uri", "definition.0.uris", "definition.0.connection"},
+						},
+
+						"uris": {
+							Description: "The AMQP Uris for the upstream, a link connects to one of them and fails over to the others.\n~> **Note:** The Uris may contain sensitive information, such as a password, the passwords are redacted in the state when they aren't the configured ones, e.g. after an import.\n~> **Note:** Exactly one of `uri`, `uris` or `connection` must be specified.",
+							Type:        schema.TypeList,
+							Optional:    true,
 							Sensitive:   true,
+							MinItems:    1,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							ExactlyOneOf: []string{"definition.0.uri", "definition.0.uris", "definition.0.connection"},
 						},
 
+						"connection": connectionSchema(
+							"The connection to the upstream, assembled into an AMQP Uri by host. The structure is described below.\n~> **Note:** Exactly one of `uri`, `uris` or `connection` must be specified.",
+							[]string{"definition.0.uri", "definition.0.uris", "definition.0.connection"},
+							false,
+						),
+
 						"prefetch_count": {
 							Description: "Maximum number of unacknowledged messages that may be in flight over a federation link at one time. Defaults to `1000`.",
 							Type:        schema.TypeInt,
@@ -89,6 +124,20 @@ func resourceFederationUpstream() *schema.Resource {
 							Optional:    true,
 							Default:     false,
 						},
+
+						"channel_use_mode": {
+							Description:  "Determines whether a link uses a `single` channel or `multiple` channels (one per direction). Possible values are `multiple` and `single`. Defaults to `multiple` on the server.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"multiple", "single"}, false),
+						},
+
+						"resource_cleanup_mode": {
+							Description:  "Determines whether the upstream resources (e.g. the internal queue of an exchange link) are deleted when the link is removed. Possible values are `default` and `never`. Defaults to `default` on the server.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"default", "never"}, false),
+						},
 						// applicable to federated exchanges only
 						"exchange": {
 							Description: "**Federated Exchanges Only**: The name of the upstream exchange.",
@@ -111,12 +160,28 @@ func resourceFederationUpstream() *schema.Resource {
 							Type:        schema.TypeInt,
 							Optional:    true,
 						},
+						"queue_type": {
+							Description:  "**Federated Exchanges Only**: The type of the internal upstream queue used by an exchange link. Possible values are `classic` and `quorum`. Defaults to `classic` on the server.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"classic", "quorum"}, false),
+						},
+						"bind_nowait": {
+							Description: "**Federated Exchanges Only**: Don't wait for the confirmation of the bindings of the internal upstream queue, which speeds up the links with many bindings.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
 						// applicable to federated queues only
 						"queue": {
 							Description: "**Federated Queues Only**: The name of the upstream queue.",
 							Type:        schema.TypeString,
 							Optional:    true,
 						},
+						"consumer_tag": {
+							Description: "**Federated Queues Only**: The consumer tag of the link on the upstream queue.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
 					},
 				},
 			},
@@ -124,8 +189,8 @@ func resourceFederationUpstream() *schema.Resource {
 	}
 }
 
-func CreateFederationUpstream(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func CreateFederationUpstream(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*Meta).Client
 
 	name := d.Get("name").(string)
 	vhost := d.Get("vhost").(string)
@@ -133,54 +198,65 @@ func CreateFederationUpstream(d *schema.ResourceData, meta interface{}) error {
 
 	defMap, ok := defList[0].(map[string]interface{})
 	if !ok {
-		return fmt.Errorf("unable to parse federation upstream definition")
+		return diag.Errorf("unable to parse federation upstream definition")
 	}
 
-	if err := putFederationUpstream(rmqc, vhost, name, defMap); err != nil {
-		return err
+	if err := putFederationUpstream(rmqc, d, vhost, name, defMap); err != nil {
+		return diag.FromErr(err)
 	}
 
 	id := fmt.Sprintf("%s@%s", name, vhost)
 	d.SetId(id)
 
-	return ReadFederationUpstream(d, meta)
+	if d.Get("wait_for_links_running").(bool) {
+		if err := waitForUpstreamLinks(ctx, rmqc, vhost, name, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diag.FromErr(ReadFederationUpstream(d, meta))
 }
 
 func ReadFederationUpstream(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
 		return err
 	}
 
-	upstream, err := rmqc.GetFederationUpstream(vhost, name)
+	upstream, err := rmqc.GetRuntimeParameter(rabbithole.FederationUpstreamComponent, vhost, name)
 	if err != nil {
 		return checkDeleted(d, err)
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Federation upstream retrieved for %s: %#v", d.Id(), upstream)
 
+	value, ok := upstream.Value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("unable to parse federation upstream '%s': %#v", d.Id(), upstream.Value)
+	}
+
 	d.Set("name", upstream.Name)
 	d.Set("vhost", upstream.Vhost)
 	d.Set("component", upstream.Component)
+	// not stored by RabbitMQ, keep the configured value or the default on import
+	d.Set("wait_for_links_running", d.Get("wait_for_links_running").(bool))
 
-	var uri string
-	if len(upstream.Definition.Uri) > 0 {
-		uri = upstream.Definition.Uri[0]
+	// the raw config isn't available on refresh, the raw state tells which attribute was used
+	rawDefinition := getRawBlock(d.GetRawConfig(), "definition")
+	if rawDefinition.IsNull() {
+		rawDefinition = getRawBlock(d.GetRawState(), "definition")
 	}
-	defMap := map[string]interface{}{
-		"uri":             uri,
-		"prefetch_count":  upstream.Definition.PrefetchCount,
-		"reconnect_delay": upstream.Definition.ReconnectDelay,
-		"ack_mode":        upstream.Definition.AckMode,
-		"trust_user_id":   upstream.Definition.TrustUserId,
-		"exchange":        upstream.Definition.Exchange,
-		"max_hops":        upstream.Definition.MaxHops,
-		"expires":         upstream.Definition.Expires,
-		"message_ttl":     upstream.Definition.MessageTTL,
-		"queue":           upstream.Definition.Queue,
+	var connection map[string]interface{}
+	if !getRawBlock(rawDefinition, "connection").IsNull() {
+		connection = map[string]interface{}{}
+		if c := getConnection(d.Get("definition.0.connection")); c != nil {
+			connection = c
+		}
 	}
+	current, _ := d.Get("definition.0").(map[string]interface{})
+	defMap := flattenFederationUpstreamDefinition(value, isRawAttributeSet(rawDefinition, "uris"), connection, configuredUpstreamUris(rawDefinition, current))
 
 	defList := [1]map[string]interface{}{defMap}
 	d.Set("definition", defList)
@@ -188,12 +264,12 @@ func ReadFederationUpstream(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func UpdateFederationUpstream(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func UpdateFederationUpstream(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*Meta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if d.HasChange("definition") {
@@ -202,19 +278,25 @@ func UpdateFederationUpstream(d *schema.ResourceData, meta interface{}) error {
 		defList := newDef.([]interface{})
 		defMap, ok := defList[0].(map[string]interface{})
 		if !ok {
-			return fmt.Errorf("unable to parse federation definition")
+			return diag.Errorf("unable to parse federation definition")
+		}
+
+		if err := putFederationUpstream(rmqc, d, vhost, name, defMap); err != nil {
+			return diag.FromErr(err)
 		}
 
-		if err := putFederationUpstream(rmqc, vhost, name, defMap); err != nil {
-			return err
+		if d.Get("wait_for_links_running").(bool) {
+			if err := waitForUpstreamLinks(ctx, rmqc, vhost, name, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return diag.FromErr(err)
+			}
 		}
 	}
 
-	return ReadFederationUpstream(d, meta)
+	return diag.FromErr(ReadFederationUpstream(d, meta))
 }
 
 func DeleteFederationUpstream(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -241,62 +323,149 @@ func DeleteFederationUpstream(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func putFederationUpstream(rmqc *rabbithole.Client, vhost string, name string, defMap map[string]interface{}) error {
-	definition := rabbithole.FederationDefinition{}
+// upstreamKeyMode tells when an attribute of the definition block is sent to the upstream parameter
+type upstreamKeyMode int
+
+const (
+	// the key is sent when the attribute isn't its zero value
+	upstreamKeyNonZero upstreamKeyMode = iota
+	// the key is always sent
+	upstreamKeyAlways
+	// the key is only sent when the attribute is set in the configuration, for the keys added by the recent
+	// versions of RabbitMQ
+	upstreamKeyConfigured
+)
+
+// upstreamKey links an attribute of the definition block to its key in the federation upstream parameter
+type upstreamKey struct {
+	attribute string
+	key       string
+	mode      upstreamKeyMode
+}
 
+// The keys of the federation upstream parameter, except the Uris
+var federationUpstreamKeys = []upstreamKey{
+	{attribute: "expires", key: "expires", mode: upstreamKeyNonZero},
+	{attribute: "message_ttl", key: "message-ttl", mode: upstreamKeyNonZero},
+	{attribute: "max_hops", key: "max-hops", mode: upstreamKeyNonZero},
+	{attribute: "prefetch_count", key: "prefetch-count", mode: upstreamKeyNonZero},
+	{attribute: "reconnect_delay", key: "reconnect-delay", mode: upstreamKeyAlways},
+	{attribute: "trust_user_id", key: "trust-user-id", mode: upstreamKeyAlways},
+	{attribute: "ack_mode", key: "ack-mode", mode: upstreamKeyNonZero},
+	{attribute: "exchange", key: "exchange", mode: upstreamKeyNonZero},
+	{attribute: "queue", key: "queue", mode: upstreamKeyNonZero},
+	{attribute: "queue_type", key: "queue-type", mode: upstreamKeyConfigured},
+	{attribute: "bind_nowait", key: "bind-nowait", mode: upstreamKeyConfigured},
+	{attribute: "resource_cleanup_mode", key: "resource-cleanup-mode", mode: upstreamKeyConfigured},
+	{attribute: "channel_use_mode", key: "channel-use-mode", mode: upstreamKeyConfigured},
+	{attribute: "consumer_tag", key: "consumer-tag", mode: upstreamKeyConfigured},
+}
+
+func putFederationUpstream(rmqc *rabbithole.Client, d *schema.ResourceData, vhost string, name string, defMap map[string]interface{}) error {
 	log.Printf("[DEBUG] RabbitMQ: Attempting to put federation definition for %s@%s: %#v", name, vhost, defMap)
 
-	if v, ok := defMap["uri"].(string); ok {
-		definition.Uri = []string{v}
-	}
+	definition := expandFederationUpstreamDefinition(getRawBlock(d.GetRawConfig(), "definition"), defMap)
 
-	if v, ok := defMap["expires"].(int); ok {
-		definition.Expires = v
-	}
+	log.Printf("[DEBUG] RabbitMQ: Attempting to declare federation upstream for %s@%s: %#v", name, vhost, definition)
 
-	if v, ok := defMap["message_ttl"].(int); ok {
-		definition.MessageTTL = int32(v)
+	resp, err := rmqc.PutRuntimeParameter(rabbithole.FederationUpstreamComponent, vhost, name, definition)
+	log.Printf("[DEBUG] RabbitMQ: Federation upstream declare response: %#v", resp)
+	if err != nil {
+		return err
 	}
 
-	if v, ok := defMap["max_hops"].(int); ok {
-		definition.MaxHops = v
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("error creating RabbitMQ federation upstream: %s", resp.Status)
 	}
 
-	if v, ok := defMap["prefetch_count"].(int); ok {
-		definition.PrefetchCount = v
-	}
+	return nil
+}
 
-	if v, ok := defMap["reconnect_delay"].(int); ok {
-		definition.ReconnectDelay = v
+// the Uris configured for the upstream, the Uris of the state may have a redacted password
+func configuredUpstreamUris(rawDefinition cty.Value, defMap map[string]interface{}) []string {
+	if uris := getRawUris(rawDefinition, "uri"); len(uris) > 0 {
+		return uris
+	} else if uris := getRawUris(rawDefinition, "uris"); len(uris) > 0 {
+		return uris
+	} else if v, ok := defMap["uri"].(string); ok && v != "" {
+		return []string{v}
+	} else if v, ok := defMap["uris"].([]interface{}); ok && len(v) > 0 {
+		uris := make([]string, len(v))
+		for i, u := range v {
+			uris[i], _ = u.(string)
+		}
+		return uris
+	} else if connection := getConnection(defMap["connection"]); connection != nil {
+		return expandConnectionUris(connection)
 	}
+	return nil
+}
 
-	if v, ok := defMap["ack_mode"].(string); ok {
-		definition.AckMode = v
-	}
+// build the value of the federation upstream parameter from the definition block
+func expandFederationUpstreamDefinition(rawDefinition cty.Value, defMap map[string]interface{}) map[string]interface{} {
+	definition := make(map[string]interface{})
 
-	if v, ok := defMap["trust_user_id"].(bool); ok {
-		definition.TrustUserId = v
+	if uris := configuredUpstreamUris(rawDefinition, defMap); len(uris) > 0 {
+		definition["uri"] = uris
 	}
 
-	if v, ok := defMap["exchange"].(string); ok {
-		definition.Exchange = v
-	}
+	for _, k := range federationUpstreamKeys {
+		v, ok := defMap[k.attribute]
+		if !ok || v == nil {
+			continue
+		}
 
-	if v, ok := defMap["queue"].(string); ok {
-		definition.Queue = v
+		switch k.mode {
+		case upstreamKeyNonZero:
+			if v == 0 || v == "" {
+				continue
+			}
+		case upstreamKeyConfigured:
+			if !isRawAttributeSet(rawDefinition, k.attribute) {
+				continue
+			}
+		}
+		definition[k.key] = v
 	}
 
-	log.Printf("[DEBUG] RabbitMQ: Attempting to declare federation upstream for %s@%s: %#v", name, vhost, definition)
+	return definition
+}
 
-	resp, err := rmqc.PutFederationUpstream(vhost, name, definition)
-	log.Printf("[DEBUG] RabbitMQ: Federation upstream declare response: %#v", resp)
-	if err != nil {
-		return err
+// build the definition block from the value of the federation upstream parameter, the Uris are set into 'connection'
+// when the current connection block isn't nil, into 'uris' when it's used by the configuration or when the upstream
+// has several Uris. The passwords of the Uris which aren't the configured ones are redacted.
+func flattenFederationUpstreamDefinition(value map[string]interface{}, useUris bool, connection map[string]interface{}, configured []string) map[string]interface{} {
+	var uris []string
+	switch v := value["uri"].(type) {
+	case string:
+		uris = []string{v}
+	case []interface{}:
+		for _, u := range v {
+			if s, ok := u.(string); ok {
+				uris = append(uris, s)
+			}
+		}
 	}
 
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("error creating RabbitMQ federation upstream: %s", resp.Status)
+	defMap := make(map[string]interface{})
+	if connection != nil {
+		defMap["connection"] = []map[string]interface{}{flattenConnectionUris(uris, connection)}
+	} else if useUris || len(uris) > 1 {
+		defMap["uris"] = stateUris(uris, configured)
+	} else if len(uris) == 1 {
+		defMap["uri"] = stateUris(uris, configured)[0]
 	}
 
-	return nil
+	for _, k := range federationUpstreamKeys {
+		v, ok := value[k.key]
+		if !ok {
+			continue
+		}
+		if n, isNumber := v.(float64); isNumber {
+			v = int(n)
+		}
+		defMap[k.attribute] = v
+	}
+
+	return defMap
 }
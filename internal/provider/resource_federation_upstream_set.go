@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+)
+
+const federationUpstreamSetComponent = "federation-upstream-set"
+
+// The settings of an upstream which can be overridden by a member of an upstream set
+var federationUpstreamSetOverrides = []policyAttribute{
+	{attribute: "exchange", key: "exchange", schema: &schema.Schema{
+		Description: "**Federated Exchanges Only**: Overrides the name of the upstream exchange.",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}},
+	{attribute: "queue", key: "queue", schema: &schema.Schema{
+		Description: "**Federated Queues Only**: Overrides the name of the upstream queue.",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}},
+	{attribute: "prefetch_count", key: "prefetch-count", schema: &schema.Schema{
+		Description:  "Overrides the maximum number of unacknowledged messages that may be in flight over a federation link at one time.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+	}},
+	{attribute: "reconnect_delay", key: "reconnect-delay", schema: &schema.Schema{
+		Description:  "Overrides the time in seconds to wait after a network link goes down before attempting reconnection.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+	}},
+	{attribute: "ack_mode", key: "ack-mode", schema: &schema.Schema{
+		Description:  "Overrides how the link should acknowledge messages. Possible values are `on-confirm`, `on-publish` and `no-ack`.",
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice([]string{"on-confirm", "on-publish", "no-ack"}, false),
+	}},
+	{attribute: "trust_user_id", key: "trust-user-id", schema: &schema.Schema{
+		Description: "Overrides how federation should interact with the validated user-id feature.",
+		Type:        schema.TypeBool,
+		Optional:    true,
+	}},
+	{attribute: "max_hops", key: "max-hops", schema: &schema.Schema{
+		Description:  "**Federated Exchanges Only**: Overrides the maximum number of federation links that messages can traverse before being dropped.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+	}},
+	{attribute: "expires", key: "expires", schema: &schema.Schema{
+		Description:  "**Federated Exchanges Only**: Overrides the expiry time (in milliseconds) of the upstream queue.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+	}},
+	{attribute: "message_ttl", key: "message-ttl", schema: &schema.Schema{
+		Description:  "**Federated Exchanges Only**: Overrides the expiry time (in milliseconds) of the messages in the upstream queue.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+	}},
+}
+
+func resourceFederationUpstreamSet() *schema.Resource {
+	member := map[string]*schema.Schema{
+		"name": {
+			Description: "The name of the federation upstream.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+	}
+	for _, o := range federationUpstreamSetOverrides {
+		member[o.attribute] = o.schema
+	}
+
+	return &schema.Resource{
+		Description: "The `rabbitmq_federation_upstream_set` resource creates and manages a federation upstream set parameter, an ordered list of federation upstreams which can be referenced by the `federation-upstream-set` key of a policy.",
+		Create:      CreateFederationUpstreamSet,
+		Read:        ReadFederationUpstreamSet,
+		Update:      UpdateFederationUpstreamSet,
+		Delete:      DeleteFederationUpstreamSet,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customizePluginDiff(pluginFederation),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The name of the federation upstream set.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"vhost": {
+				Description: "The vhost to create the resource in.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"upstream": {
+				Description: "The upstreams of the set, in order. Each upstream can override the settings of the federation upstream it references. The structure is described below.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: member,
+				},
+			},
+		},
+	}
+}
+
+func CreateFederationUpstreamSet(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	name := d.Get("name").(string)
+	vhost := d.Get("vhost").(string)
+
+	if err := putFederationUpstreamSet(rmqc, d, vhost, name); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s@%s", name, vhost))
+
+	return ReadFederationUpstreamSet(d, meta)
+}
+
+func ReadFederationUpstreamSet(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	name, vhost, err := parseResourceId(d)
+	if err != nil {
+		return err
+	}
+
+	parameter, err := rmqc.GetRuntimeParameter(federationUpstreamSetComponent, vhost, name)
+	if err != nil {
+		return checkDeleted(d, err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Federation upstream set retrieved for %s: %#v", d.Id(), parameter)
+
+	members, ok := parameter.Value.([]interface{})
+	if !ok {
+		return fmt.Errorf("unable to parse federation upstream set '%s': %#v", d.Id(), parameter.Value)
+	}
+
+	d.Set("name", parameter.Name)
+	d.Set("vhost", parameter.Vhost)
+	d.Set("upstream", flattenFederationUpstreamSet(members))
+
+	return nil
+}
+
+func UpdateFederationUpstreamSet(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	name, vhost, err := parseResourceId(d)
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("upstream") {
+		if err := putFederationUpstreamSet(rmqc, d, vhost, name); err != nil {
+			return err
+		}
+	}
+
+	return ReadFederationUpstreamSet(d, meta)
+}
+
+func DeleteFederationUpstreamSet(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	name, vhost, err := parseResourceId(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to delete federation upstream set %s", d.Id())
+
+	resp, err := rmqc.DeleteRuntimeParameter(federationUpstreamSetComponent, vhost, name)
+	if err != nil || (resp.StatusCode >= 400 && resp.StatusCode != 404) {
+		return failApiResponse(err, resp, "deleting", "federation upstream set")
+	}
+
+	return nil
+}
+
+func putFederationUpstreamSet(rmqc *rabbithole.Client, d *schema.ResourceData, vhost string, name string) error {
+	members := expandFederationUpstreamSet(d.GetRawConfig(), d.Get("upstream").([]interface{}))
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to put federation upstream set %s@%s: %#v", name, vhost, members)
+
+	resp, err := rmqc.PutRuntimeParameter(federationUpstreamSetComponent, vhost, name, members)
+	if err != nil || resp.StatusCode >= 400 {
+		return failApiResponse(err, resp, "putting", "federation upstream set")
+	}
+
+	return nil
+}
+
+// build the members of the set, only the overrides set in the configuration are sent
+func expandFederationUpstreamSet(rawConfig cty.Value, upstreams []interface{}) []map[string]interface{} {
+	members := make([]map[string]interface{}, len(upstreams))
+
+	for i, u := range upstreams {
+		upstream := u.(map[string]interface{})
+		rawUpstream := getRawBlockElement(rawConfig, "upstream", i)
+
+		member := map[string]interface{}{"upstream": upstream["name"]}
+		for _, o := range federationUpstreamSetOverrides {
+			if isRawAttributeSet(rawUpstream, o.attribute) {
+				member[o.key] = upstream[o.attribute]
+			}
+		}
+		members[i] = member
+	}
+
+	return members
+}
+
+func flattenFederationUpstreamSet(members []interface{}) []map[string]interface{} {
+	upstreams := make([]map[string]interface{}, 0, len(members))
+
+	for _, m := range members {
+		member, ok := m.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		upstream := map[string]interface{}{"name": member["upstream"]}
+		for _, o := range federationUpstreamSetOverrides {
+			value, ok := member[o.key]
+			if !ok {
+				continue
+			}
+			if v, isNumber := value.(float64); isNumber {
+				value = int(v)
+			}
+			upstream[o.attribute] = value
+		}
+		upstreams = append(upstreams, upstream)
+	}
+
+	return upstreams
+}
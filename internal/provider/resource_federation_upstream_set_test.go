@@ -0,0 +1,158 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/acceptance"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider"
+)
+
+func TestAccFederationUpstreamSet(t *testing.T) {
+	resourceName := "rabbitmq_federation_upstream_set.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAcc.PreCheck(t) },
+		Providers:    acceptance.TestAcc.Providers,
+		CheckDestroy: testAccParameterCheckDestroy("federation-upstream-set", "test", "regions"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFederationUpstreamSetConfig(`
+    upstream {
+        name = rabbitmq_federation_upstream.eu.name
+    }`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccParameterCheck("federation-upstream-set", "test", "regions", `[{"upstream":"eu"}]`),
+					resource.TestCheckResourceAttr(resourceName, "id", "regions@test"),
+					resource.TestCheckResourceAttr(resourceName, "upstream.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "upstream.0.name", "eu"),
+				),
+			},
+			{
+				Config: testAccFederationUpstreamSetConfig(`
+    upstream {
+        name = rabbitmq_federation_upstream.us.name
+        exchange = "events"
+        max_hops = 2
+        trust_user_id = false
+    }
+    upstream {
+        name = rabbitmq_federation_upstream.eu.name
+    }`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccParameterCheck("federation-upstream-set", "test", "regions", `[{"exchange":"events","max-hops":2,"trust-user-id":false,"upstream":"us"},{"upstream":"eu"}]`),
+					resource.TestCheckResourceAttr(resourceName, "upstream.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "upstream.0.name", "us"),
+					resource.TestCheckResourceAttr(resourceName, "upstream.0.exchange", "events"),
+					resource.TestCheckResourceAttr(resourceName, "upstream.0.max_hops", "2"),
+					resource.TestCheckResourceAttr(resourceName, "upstream.1.name", "eu"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccFederation(t *testing.T) {
+	resourceName := "rabbitmq_federation.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAcc.PreCheck(t) },
+		Providers: acceptance.TestAcc.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFederationConfig(`upstream_set = rabbitmq_federation_upstream_set.test.name`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccFederationCheckPolicy("test", "federate", `{"federation-upstream-set":"regions"}`),
+					resource.TestCheckResourceAttr(resourceName, "upstream_set", "regions"),
+					resource.TestCheckResourceAttr(resourceName, "upstream", ""),
+					resource.TestCheckResourceAttr(resourceName, "priority", "0"),
+				),
+			},
+			{
+				Config: testAccFederationConfig(`upstream = rabbitmq_federation_upstream.eu.name`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccFederationCheckPolicy("test", "federate", `{"federation-upstream":"eu"}`),
+					resource.TestCheckResourceAttr(resourceName, "upstream", "eu"),
+					resource.TestCheckResourceAttr(resourceName, "upstream_set", ""),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccFederationCheckPolicy(vhost string, name string, definition string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := acceptance.TestAcc.Provider.Meta().(*provider.Meta).Client
+
+		policy, err := rmqc.GetPolicy(vhost, name)
+		if err != nil {
+			return fmt.Errorf("error retrieving policy '%s@%s': %#v", name, vhost, err)
+		}
+
+		if v, _ := json.Marshal(policy.Definition); string(v) != definition {
+			return fmt.Errorf("policy '%s@%s' has the definition %s, expected %s", name, vhost, v, definition)
+		}
+
+		return nil
+	}
+}
+
+const testAccFederationUpstreamSetBaseConfig = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_federation_upstream" "eu" {
+    name = "eu"
+    vhost = rabbitmq_vhost.test.name
+    definition {
+        uri = "amqp://eu.server-name"
+    }
+}
+
+resource "rabbitmq_federation_upstream" "us" {
+    name = "us"
+    vhost = rabbitmq_vhost.test.name
+    definition {
+        uri = "amqp://us.server-name"
+    }
+}
+`
+
+func testAccFederationUpstreamSetConfig(upstreams string) string {
+	return testAccFederationUpstreamSetBaseConfig + fmt.Sprintf(`
+resource "rabbitmq_federation_upstream_set" "test" {
+    name = "regions"
+    vhost = rabbitmq_vhost.test.name
+%s
+}
+`, upstreams)
+}
+
+func testAccFederationConfig(upstream string) string {
+	return testAccFederationUpstreamSetConfig(`
+    upstream {
+        name = rabbitmq_federation_upstream.eu.name
+    }`) + fmt.Sprintf(`
+resource "rabbitmq_federation" "test" {
+    name = "federate"
+    vhost = rabbitmq_vhost.test.name
+    pattern = "^federated\\."
+    apply_to = "exchanges"
+    %s
+}
+`, upstream)
+}
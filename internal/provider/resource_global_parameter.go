@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+)
+
+func resourceGlobalParameter() *schema.Resource {
+	return &schema.Resource{
+		Description: "The `rabbitmq_global_parameter` resource creates and manages a global runtime parameter (e.g. `cluster_name`, `mqtt_port_to_vhost_mapping` or `mqtt_default_vhosts`).",
+		Create:      CreateGlobalParameter,
+		Update:      UpdateGlobalParameter,
+		Read:        ReadGlobalParameter,
+		Delete:      DeleteGlobalParameter,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The name of the global parameter.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"value": {
+				Description:      "The value of the global parameter as a JSON document, e.g. `jsonencode(\"my-cluster\")` for `cluster_name`.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+		},
+	}
+}
+
+func CreateGlobalParameter(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	name := d.Get("name").(string)
+
+	if err := putGlobalParameter(rmqc, name, d.Get("value").(string)); err != nil {
+		return err
+	}
+
+	d.SetId(name)
+
+	return ReadGlobalParameter(d, meta)
+}
+
+func ReadGlobalParameter(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	parameter, err := rmqc.GetGlobalParameter(d.Id())
+	if err != nil {
+		return checkDeleted(d, err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Global parameter retrieved for %s: %#v", d.Id(), parameter)
+
+	value, err := parameterValueToJson(parameter.Value)
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", parameter.Name)
+	d.Set("value", value)
+
+	return nil
+}
+
+func UpdateGlobalParameter(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	if d.HasChange("value") {
+		if err := putGlobalParameter(rmqc, d.Id(), d.Get("value").(string)); err != nil {
+			return err
+		}
+	}
+
+	return ReadGlobalParameter(d, meta)
+}
+
+func DeleteGlobalParameter(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to delete global parameter %s", d.Id())
+
+	resp, err := rmqc.DeleteGlobalParameter(d.Id())
+	if err != nil || (resp.StatusCode >= 400 && resp.StatusCode != 404) {
+		return failApiResponse(err, resp, "deleting", "global parameter")
+	}
+
+	return nil
+}
+
+func putGlobalParameter(rmqc *rabbithole.Client, name string, valueJson string) error {
+	value, err := parameterValueFromJson(valueJson)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to put global parameter %s: %s", name, valueJson)
+
+	resp, err := rmqc.PutGlobalParameter(name, value)
+	if err != nil || resp.StatusCode >= 400 {
+		return failApiResponse(err, resp, "putting", "global parameter")
+	}
+
+	return nil
+}
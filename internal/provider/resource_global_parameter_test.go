@@ -0,0 +1,87 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/acceptance"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider"
+)
+
+func TestAccGlobalParameter(t *testing.T) {
+	resourceName := "rabbitmq_global_parameter.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAcc.PreCheck(t) },
+		Providers:    acceptance.TestAcc.Providers,
+		CheckDestroy: testAccGlobalParameterCheckDestroy("tf_acc_test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalParameterConfig(`{"a": "b"}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccGlobalParameterCheck("tf_acc_test", `{"a":"b"}`),
+					resource.TestCheckResourceAttr(resourceName, "id", "tf_acc_test"),
+					resource.TestCheckResourceAttr(resourceName, "value", `{"a":"b"}`),
+					resource.TestCheckResourceAttr("data.rabbitmq_global_parameter.test", "value", `{"a":"b"}`),
+				),
+			},
+			{
+				Config: testAccGlobalParameterConfig(`"my-value"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccGlobalParameterCheck("tf_acc_test", `"my-value"`),
+					resource.TestCheckResourceAttr(resourceName, "value", `"my-value"`),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccGlobalParameterCheck(name string, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := acceptance.TestAcc.Provider.Meta().(*provider.Meta).Client
+
+		parameter, err := rmqc.GetGlobalParameter(name)
+		if err != nil {
+			return fmt.Errorf("error retrieving global parameter '%s': %#v", name, err)
+		}
+
+		if v, _ := json.Marshal(parameter.Value); string(v) != value {
+			return fmt.Errorf("global parameter '%s' has the value %s, expected %s", name, v, value)
+		}
+
+		return nil
+	}
+}
+
+func testAccGlobalParameterCheckDestroy(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := acceptance.TestAcc.Provider.Meta().(*provider.Meta).Client
+
+		if _, err := rmqc.GetGlobalParameter(name); err == nil {
+			return fmt.Errorf("global parameter '%s' still exists", name)
+		}
+
+		return nil
+	}
+}
+
+func testAccGlobalParameterConfig(value string) string {
+	return fmt.Sprintf(`
+resource "rabbitmq_global_parameter" "test" {
+    name = "tf_acc_test"
+    value = jsonencode(%s)
+}
+
+data "rabbitmq_global_parameter" "test" {
+    name = rabbitmq_global_parameter.test.name
+}
+`, value)
+}
@@ -9,6 +9,9 @@ import (
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/utils"
 )
 
 func resourceOperatorPolicy() *schema.Resource {
@@ -21,6 +24,7 @@ func resourceOperatorPolicy() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customizePolicyDiff(true),
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -45,9 +49,10 @@ func resourceOperatorPolicy() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"pattern": {
-							Description: "A pattern to match an exchange or queue name.",
-							Type:        schema.TypeString,
-							Required:    true,
+							Description:  "A pattern to match an exchange or queue name.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: utils.ValidateErlangRegex,
 						},
 
 						"priority": {
@@ -57,15 +62,17 @@ func resourceOperatorPolicy() *schema.Resource {
 						},
 
 						"apply_to": {
-							Description: "Can be `queues`.",
-							Type:        schema.TypeString,
-							Required:    true,
+							Description:  "Can be `queues`, `classic_queues`, `quorum_queues` or `streams`.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(operatorPolicyApplyTo, false),
 						},
 
 						"definition": {
-							Description: "Key/value pairs of the operator policy definition.\n-> **Note:** See the RabbitMQ documentation for definition references and examples.",
-							Type:        schema.TypeMap,
-							Required:    true,
+							Description:      "Key/value pairs of the operator policy definition.\n-> **Note:** See the RabbitMQ documentation for definition references and examples.",
+							Type:             schema.TypeMap,
+							Required:         true,
+							ValidateDiagFunc: validatePolicyDefinitionKeys,
 						},
 					},
 				},
@@ -75,7 +82,7 @@ func resourceOperatorPolicy() *schema.Resource {
 }
 
 func CreateOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name := d.Get("name").(string)
 	vhost := d.Get("vhost").(string)
@@ -96,7 +103,7 @@ func CreateOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
 }
 
 func ReadOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -144,7 +151,7 @@ func ReadOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
 }
 
 func UpdateOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -169,7 +176,7 @@ func UpdateOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
 }
 
 func DeleteOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
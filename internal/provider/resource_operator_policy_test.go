@@ -2,6 +2,7 @@ package provider_test
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 	"github.com/rfd59/terraform-provider-rabbitmq/internal/acceptance"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider"
 )
 
 func TestAccOperatorPolicy(t *testing.T) {
@@ -46,6 +48,19 @@ func TestAccOperatorPolicy(t *testing.T) {
 	})
 }
 
+func TestAccOperatorPolicy_InvalidDefinition(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAcc.PreCheck(t) },
+		Providers: acceptance.TestAcc.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccOperatorPolicyConfig_invalidKey,
+				ExpectError: regexp.MustCompile(`the key 'dead-letter-exchange' can't be used in an operator policy`),
+			},
+		},
+	})
+}
+
 func testAccOperatorPolicyCheck(rn string, operatorPolicy *rabbithole.OperatorPolicy) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -57,7 +72,7 @@ func testAccOperatorPolicyCheck(rn string, operatorPolicy *rabbithole.OperatorPo
 			return fmt.Errorf("operator policy id not set")
 		}
 
-		rmqc := acceptance.TestAcc.Provider.Meta().(*rabbithole.Client)
+		rmqc := acceptance.TestAcc.Provider.Meta().(*provider.Meta).Client
 		operatorPolicyParts := strings.Split(rs.Primary.ID, "@")
 
 		operatorPolicies, err := rmqc.ListOperatorPolicies()
@@ -78,7 +93,7 @@ func testAccOperatorPolicyCheck(rn string, operatorPolicy *rabbithole.OperatorPo
 
 func testAccOperatorPolicyCheckDestroy(operatorPolicy *rabbithole.OperatorPolicy) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := acceptance.TestAcc.Provider.Meta().(*rabbithole.Client)
+		rmqc := acceptance.TestAcc.Provider.Meta().(*provider.Meta).Client
 
 		operatorPolicies, err := rmqc.ListOperatorPolicies()
 		if err != nil {
@@ -151,3 +166,17 @@ resource "rabbitmq_operator_policy" "test" {
         }
     }
 }`
+
+const testAccOperatorPolicyConfig_invalidKey = `
+resource "rabbitmq_operator_policy" "test" {
+    name = "test"
+    vhost = "test"
+    policy {
+        pattern = ".*"
+        priority = 1
+        apply_to = "queues"
+        definition = {
+            dead-letter-exchange = "dlx"
+        }
+    }
+}`
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+)
+
+func resourceParameter() *schema.Resource {
+	return &schema.Resource{
+		Description: "The `rabbitmq_parameter` resource creates and manages a vhost-scoped runtime parameter (e.g. of the `federation-upstream-set` component).\n~> **Note:** The `federation-upstream` and `shovel` parameters should be managed by the `rabbitmq_federation_upstream` and `rabbitmq_shovel` resources.",
+		Create:      CreateParameter,
+		Update:      UpdateParameter,
+		Read:        ReadParameter,
+		Delete:      DeleteParameter,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"component": {
+				Description: "The component of the parameter (e.g. `federation-upstream-set`).",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description: "The name of the parameter.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"vhost": {
+				Description: "The vhost of the parameter. Defaults to `/`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "/",
+				ForceNew:    true,
+			},
+			"value": {
+				Description:      "The value of the parameter as a JSON document.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+		},
+	}
+}
+
+func CreateParameter(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	component := d.Get("component").(string)
+	name := d.Get("name").(string)
+	vhost := d.Get("vhost").(string)
+	id := fmt.Sprintf("%s/%s@%s", component, name, vhost)
+
+	// Check if already exists
+	if _, err := rmqc.GetRuntimeParameter(component, vhost, name); err == nil {
+		return fmt.Errorf("error creating RabbitMQ parameter '%s': parameter already exists, import it with the ID '%s'", name, id)
+	}
+
+	if err := putParameter(rmqc, component, vhost, name, d.Get("value").(string)); err != nil {
+		return err
+	}
+
+	d.SetId(id)
+
+	return ReadParameter(d, meta)
+}
+
+func ReadParameter(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	component, name, vhost, err := parseParameterId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	parameter, err := rmqc.GetRuntimeParameter(component, vhost, name)
+	if err != nil {
+		return checkDeleted(d, err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Parameter retrieved for %s: %#v", d.Id(), parameter)
+
+	value, err := parameterValueToJson(parameter.Value)
+	if err != nil {
+		return err
+	}
+
+	d.Set("component", parameter.Component)
+	d.Set("name", parameter.Name)
+	d.Set("vhost", parameter.Vhost)
+	d.Set("value", value)
+
+	return nil
+}
+
+func UpdateParameter(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	component, name, vhost, err := parseParameterId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("value") {
+		if err := putParameter(rmqc, component, vhost, name, d.Get("value").(string)); err != nil {
+			return err
+		}
+	}
+
+	return ReadParameter(d, meta)
+}
+
+func DeleteParameter(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	component, name, vhost, err := parseParameterId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to delete parameter %s", d.Id())
+
+	resp, err := rmqc.DeleteRuntimeParameter(component, vhost, name)
+	if err != nil || (resp.StatusCode >= 400 && resp.StatusCode != 404) {
+		return failApiResponse(err, resp, "deleting", "parameter")
+	}
+
+	return nil
+}
+
+func putParameter(rmqc *rabbithole.Client, component string, vhost string, name string, valueJson string) error {
+	value, err := parameterValueFromJson(valueJson)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to put parameter %s/%s@%s: %s", component, name, vhost, valueJson)
+
+	resp, err := rmqc.PutRuntimeParameter(component, vhost, name, value)
+	if err != nil || resp.StatusCode >= 400 {
+		return failApiResponse(err, resp, "putting", "parameter")
+	}
+
+	return nil
+}
+
+// get the component, the name and the vhost of a parameter from the resource id 'component/name@vhost'
+func parseParameterId(resourceId string) (component, name, vhost string, err error) {
+	componentName, vhost, err := parseId(resourceId)
+	if err != nil {
+		return
+	}
+
+	parts := strings.SplitN(componentName, "/", 2)
+	if len(parts) != 2 {
+		err = fmt.Errorf("unable to parse resource id: %s", resourceId)
+		return
+	}
+
+	return parts[0], parts[1], vhost, nil
+}
+
+// decode the JSON value of a parameter, the numbers are kept as written
+func parameterValueFromJson(valueJson string) (interface{}, error) {
+	var value interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(valueJson)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("unable to parse the parameter value: %v", err)
+	}
+
+	return value, nil
+}
+
+func parameterValueToJson(value interface{}) (string, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode the parameter value: %v", err)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,125 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/acceptance"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider"
+)
+
+func TestAccParameter(t *testing.T) {
+	resourceName := "rabbitmq_parameter.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAcc.PreCheck(t) },
+		Providers:    acceptance.TestAcc.Providers,
+		CheckDestroy: testAccParameterCheckDestroy("federation-upstream-set", "test", "upstreams"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterConfig(`[{"upstream": "a"}]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccParameterCheck("federation-upstream-set", "test", "upstreams", `[{"upstream":"a"}]`),
+					resource.TestCheckResourceAttr(resourceName, "id", "federation-upstream-set/upstreams@test"),
+					resource.TestCheckResourceAttr(resourceName, "value", `[{"upstream":"a"}]`),
+					resource.TestCheckResourceAttr("data.rabbitmq_parameter.test", "value", `[{"upstream":"a"}]`),
+				),
+			},
+			{
+				Config: testAccParameterConfig(`[{"upstream": "a"}, {"upstream": "b"}]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccParameterCheck("federation-upstream-set", "test", "upstreams", `[{"upstream":"a"},{"upstream":"b"}]`),
+					resource.TestCheckResourceAttr(resourceName, "value", `[{"upstream":"a"},{"upstream":"b"}]`),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccParameter_AlreadyExists(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAcc.PreCheck(t) },
+		Providers: acceptance.TestAcc.Providers,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					rmqc := acceptance.TestAcc.Provider.Meta().(*provider.Meta).Client
+					if _, err := rmqc.PutRuntimeParameter("federation-upstream-set", "/", "existing", []map[string]interface{}{{"upstream": "a"}}); err != nil {
+						t.Fatalf("error creating the parameter: %#v", err)
+					}
+					t.Cleanup(func() { rmqc.DeleteRuntimeParameter("federation-upstream-set", "/", "existing") })
+				},
+				Config:      testAccParameterConfigExisting,
+				ExpectError: regexp.MustCompile("parameter already exists, import it with the ID 'federation-upstream-set/existing@/'"),
+			},
+		},
+	})
+}
+
+func testAccParameterCheck(component string, vhost string, name string, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := acceptance.TestAcc.Provider.Meta().(*provider.Meta).Client
+
+		parameter, err := rmqc.GetRuntimeParameter(component, vhost, name)
+		if err != nil {
+			return fmt.Errorf("error retrieving parameter '%s/%s@%s': %#v", component, name, vhost, err)
+		}
+
+		if v, _ := json.Marshal(parameter.Value); string(v) != value {
+			return fmt.Errorf("parameter '%s/%s@%s' has the value %s, expected %s", component, name, vhost, v, value)
+		}
+
+		return nil
+	}
+}
+
+func testAccParameterCheckDestroy(component string, vhost string, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := acceptance.TestAcc.Provider.Meta().(*provider.Meta).Client
+
+		if _, err := rmqc.GetRuntimeParameter(component, vhost, name); err == nil {
+			return fmt.Errorf("parameter '%s/%s@%s' still exists", component, name, vhost)
+		}
+
+		return nil
+	}
+}
+
+func testAccParameterConfig(value string) string {
+	return fmt.Sprintf(`
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_parameter" "test" {
+    component = "federation-upstream-set"
+    name = "upstreams"
+    vhost = rabbitmq_vhost.test.name
+    value = jsonencode(%s)
+}
+
+data "rabbitmq_parameter" "test" {
+    component = rabbitmq_parameter.test.component
+    name = rabbitmq_parameter.test.name
+    vhost = rabbitmq_parameter.test.vhost
+}
+`, value)
+}
+
+const testAccParameterConfigExisting = `
+resource "rabbitmq_parameter" "test" {
+    component = "federation-upstream-set"
+    name = "existing"
+    vhost = "/"
+    value = jsonencode([{"upstream": "a"}])
+}
+`
@@ -7,6 +7,8 @@ import (
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/utils"
 )
 
 func resourcePermissions() *schema.Resource {
@@ -44,21 +46,24 @@ func resourcePermissions() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"configure": {
-							Description: "The _configure_ ACL",
-							Type:        schema.TypeString,
-							Required:    true,
+							Description:  "The _configure_ ACL",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: utils.ValidateErlangRegex,
 						},
 
 						"write": {
-							Description: "The _write_ ACL",
-							Type:        schema.TypeString,
-							Required:    true,
+							Description:  "The _write_ ACL",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: utils.ValidateErlangRegex,
 						},
 
 						"read": {
-							Description: "The _read_ ACL",
-							Type:        schema.TypeString,
-							Required:    true,
+							Description:  "The _read_ ACL",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: utils.ValidateErlangRegex,
 						},
 					},
 				},
@@ -68,7 +73,7 @@ func resourcePermissions() *schema.Resource {
 }
 
 func CreatePermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	user := d.Get("user").(string)
 	vhost := d.Get("vhost").(string)
@@ -90,7 +95,7 @@ func CreatePermissions(d *schema.ResourceData, meta interface{}) error {
 }
 
 func ReadPermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	user, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -119,7 +124,7 @@ func ReadPermissions(d *schema.ResourceData, meta interface{}) error {
 }
 
 func UpdatePermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	user, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -144,7 +149,7 @@ func UpdatePermissions(d *schema.ResourceData, meta interface{}) error {
 }
 
 func DeletePermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	user, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -2,6 +2,7 @@ package provider_test
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider"
 )
 
 func TestAccPermissions(t *testing.T) {
@@ -72,6 +74,30 @@ resource "rabbitmq_permissions" "test" {
 	})
 }
 
+func TestAccPermissions_InvalidRegex(t *testing.T) {
+
+	config := `
+resource "rabbitmq_permissions" "test" {
+    user = "mctest"
+    vhost = "test"
+    permissions {
+        configure = ".*"
+        write = "^(orders"
+        read = ".*"
+    }
+}`
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAcc.PreCheck(t) },
+		Providers: acceptance.TestAcc.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`"\^\(orders" is not a valid regular expression`),
+			},
+		},
+	})
+}
+
 func testAccPermissionsCheck(rn string, permissionInfo *rabbithole.PermissionInfo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -83,7 +109,7 @@ func testAccPermissionsCheck(rn string, permissionInfo *rabbithole.PermissionInf
 			return fmt.Errorf("permission id not set")
 		}
 
-		rmqc := acceptance.TestAcc.Provider.Meta().(*rabbithole.Client)
+		rmqc := acceptance.TestAcc.Provider.Meta().(*provider.Meta).Client
 		perms, err := rmqc.ListPermissions()
 		if err != nil {
 			return fmt.Errorf("error retrieving permissions: %s", err)
@@ -103,7 +129,7 @@ func testAccPermissionsCheck(rn string, permissionInfo *rabbithole.PermissionInf
 
 func testAccPermissionsCheckDestroy(permissionInfo *rabbithole.PermissionInfo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := acceptance.TestAcc.Provider.Meta().(*rabbithole.Client)
+		rmqc := acceptance.TestAcc.Provider.Meta().(*provider.Meta).Client
 		perms, err := rmqc.ListPermissions()
 		if err != nil {
 			return fmt.Errorf("error retrieving permissions: %s", err)
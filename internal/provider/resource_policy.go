@@ -3,24 +3,27 @@ package provider
 import (
 	"fmt"
 	"log"
-	"strconv"
-	"strings"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/utils"
 )
 
 func resourcePolicy() *schema.Resource {
 	return &schema.Resource{
-		Description: "The `rabbitmq_policy` resource creates and manages policies for exchanges and queues.",
-		Create:      CreatePolicy,
-		Update:      UpdatePolicy,
-		Read:        ReadPolicy,
-		Delete:      DeletePolicy,
+		Description:   "The `rabbitmq_policy` resource creates and manages policies for exchanges and queues.",
+		CreateContext: withDeprecationWarnings(CreatePolicy, resourcePolicyDeprecations),
+		UpdateContext: withDeprecationWarnings(UpdatePolicy, resourcePolicyDeprecations),
+		Read:          ReadPolicy,
+		Delete:        DeletePolicy,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customizePolicyDiff(false),
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -43,11 +46,12 @@ func resourcePolicy() *schema.Resource {
 				Required:    true,
 				MaxItems:    1,
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
+					Schema: policyDefinitionSchema(map[string]*schema.Schema{
 						"pattern": {
-							Description: "A pattern to match an exchange or queue name.",
-							Type:        schema.TypeString,
-							Required:    true,
+							Description:  "A pattern to match an exchange or queue name.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: utils.ValidateErlangRegex,
 						},
 
 						"priority": {
@@ -57,17 +61,12 @@ func resourcePolicy() *schema.Resource {
 						},
 
 						"apply_to": {
-							Description: "Can either be `exchanges`, `queues`, or `all`.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-
-						"definition": {
-							Description: "Key/value pairs of the policy definition.\n-> **Note:** See the RabbitMQ documentation for definition references and examples.",
-							Type:        schema.TypeMap,
-							Required:    true,
+							Description:  "Can be `exchanges`, `queues`, `all`, `classic_queues`, `quorum_queues` or `streams`.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(policyApplyTo, false),
 						},
-					},
+					}, "policy"),
 				},
 			},
 		},
@@ -75,7 +74,7 @@ func resourcePolicy() *schema.Resource {
 }
 
 func CreatePolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name := d.Get("name").(string)
 	vhost := d.Get("vhost").(string)
@@ -93,7 +92,7 @@ func CreatePolicy(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("unable to parse policy")
 	}
 
-	if err := putPolicy(rmqc, vhost, name, policyMap); err != nil {
+	if err := putPolicy(rmqc, d, vhost, name, policyMap); err != nil {
 		return err
 	}
 
@@ -104,7 +103,7 @@ func CreatePolicy(d *schema.ResourceData, meta interface{}) error {
 }
 
 func ReadPolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -127,23 +126,9 @@ func ReadPolicy(d *schema.ResourceData, meta interface{}) error {
 	p["priority"] = policy.Priority
 	p["apply_to"] = policy.ApplyTo
 
-	policyDefinition := make(map[string]interface{})
-	for key, value := range policy.Definition {
-		switch v := value.(type) {
-		case float64:
-			value = strconv.FormatFloat(v, 'f', -1, 64)
-		case []interface{}:
-			var nodes []string
-			for _, node := range v {
-				if n, ok := node.(string); ok {
-					nodes = append(nodes, n)
-				}
-			}
-			value = strings.Join(nodes, ",")
-		}
-		policyDefinition[key] = value
+	if err := flattenPolicyDefinition(d, "policy", policy.Definition, p); err != nil {
+		return err
 	}
-	p["definition"] = policyDefinition
 	setPolicy[0] = p
 
 	d.Set("policy", setPolicy)
@@ -152,7 +137,7 @@ func ReadPolicy(d *schema.ResourceData, meta interface{}) error {
 }
 
 func UpdatePolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -168,7 +153,7 @@ func UpdatePolicy(d *schema.ResourceData, meta interface{}) error {
 			return fmt.Errorf("unable to parse policy")
 		}
 
-		if err := putPolicy(rmqc, vhost, name, policyMap); err != nil {
+		if err := putPolicy(rmqc, d, vhost, name, policyMap); err != nil {
 			return err
 		}
 	}
@@ -177,7 +162,7 @@ func UpdatePolicy(d *schema.ResourceData, meta interface{}) error {
 }
 
 func DeletePolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -204,7 +189,21 @@ func DeletePolicy(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func putPolicy(rmqc *rabbithole.Client, vhost string, name string, policyMap map[string]interface{}) error {
+func resourcePolicyDeprecations(d *schema.ResourceData, version string) diag.Diagnostics {
+	policyMap, ok := d.Get("policy.0").(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	definition, err := expandPolicyDefinition(d, "policy", policyMap)
+	if err != nil {
+		return nil
+	}
+
+	return policyDeprecations(definition, version)
+}
+
+func putPolicy(rmqc *rabbithole.Client, d *schema.ResourceData, vhost string, name string, policyMap map[string]interface{}) error {
 	policy := rabbithole.Policy{}
 	policy.Vhost = vhost
 	policy.Name = name
@@ -221,27 +220,11 @@ func putPolicy(rmqc *rabbithole.Client, vhost string, name string, policyMap map
 		policy.ApplyTo = v
 	}
 
-	if v, ok := policyMap["definition"].(map[string]interface{}); ok {
-		// special case for ha-mode = nodes
-		if x, ok := v["ha-mode"]; ok && x == "nodes" {
-			var nodes rabbithole.NodeNames
-			if _, ok := v["ha-params"].(string); ok {
-				nodes = strings.Split(v["ha-params"].(string), ",")
-				v["ha-params"] = nodes
-			}
-		}
-
-		// special case for integers
-		for key, val := range v {
-			if x, ok := val.(string); ok {
-				if x, err := strconv.ParseInt(x, 10, 64); err == nil {
-					v[key] = x
-				}
-			}
-		}
-
-		policy.Definition = v
+	definition, err := expandPolicyDefinition(d, "policy", policyMap)
+	if err != nil {
+		return fmt.Errorf("error declaring RabbitMQ policy '%s': %v", name, err)
 	}
+	policy.Definition = definition
 
 	log.Printf("[DEBUG] RabbitMQ: Attempting to declare policy for %s@%s: %#v", name, vhost, policy)
 
@@ -2,6 +2,8 @@ package provider_test
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -10,6 +12,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider"
 )
 
 func TestAccPolicy(t *testing.T) {
@@ -35,6 +38,132 @@ func TestAccPolicy(t *testing.T) {
 	})
 }
 
+func TestAccPolicy_ClassicMirroring(t *testing.T) {
+	var policy rabbithole.Policy
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAcc.PreCheck(t)
+			if acceptance.TestAcc.ValidFeature("4.0") {
+				t.Skip("Classic queue mirroring was removed in RabbitMQ 4.0")
+			}
+		},
+		Providers:    acceptance.TestAcc.Providers,
+		CheckDestroy: testAccPolicyCheckDestroy(&policy),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyConfig_classicMirroring,
+				Check: testAccPolicyCheck(
+					"rabbitmq_policy.test", &policy,
+				),
+			},
+		},
+	})
+}
+
+func TestAccPolicy_ClassicMirroringRemoved(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAcc.PreCheck(t)
+			if !acceptance.TestAcc.ValidFeature("4.0") {
+				t.Skip("Classic queue mirroring is removed since RabbitMQ 4.0")
+			}
+		},
+		Providers: acceptance.TestAcc.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPolicyConfig_classicMirroring,
+				ExpectError: regexp.MustCompile(`Classic queue mirroring is not supported by RabbitMQ`),
+			},
+		},
+	})
+}
+
+func TestAccPolicy_DefinitionJson(t *testing.T) {
+	var policy rabbithole.Policy
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAcc.PreCheck(t) },
+		Providers:    acceptance.TestAcc.Providers,
+		CheckDestroy: testAccPolicyCheckDestroy(&policy),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyConfig_definitionJson,
+				Check: resource.ComposeTestCheckFunc(
+					testAccPolicyCheck("rabbitmq_policy.test", &policy),
+					testAccPolicyCheckDefinition("rabbitmq_policy.test", map[string]interface{}{
+						"max-length":           float64(1000),
+						"message-ttl":          float64(60000),
+						"dead-letter-exchange": "dlx",
+						"overflow":             "reject-publish",
+						"consumer-timeout":     float64(3600000),
+					}),
+					resource.TestCheckResourceAttr("rabbitmq_policy.test", "policy.0.max_length", "1000"),
+					resource.TestCheckResourceAttr("rabbitmq_policy.test", "policy.0.dead_letter_exchange", "dlx"),
+				),
+			},
+			{
+				ResourceName:            "rabbitmq_policy.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"policy"},
+			},
+		},
+	})
+}
+
+func TestAccPolicy_DuplicateKey(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAcc.PreCheck(t) },
+		Providers: acceptance.TestAcc.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPolicyConfig_duplicateKey,
+				ExpectError: regexp.MustCompile("the policy definition key 'max-length' is set by the `max_length` attribute"),
+			},
+		},
+	})
+}
+
+func TestAccPolicy_InvalidDefinition(t *testing.T) {
+	config := func(applyTo string, definition string) string {
+		return fmt.Sprintf(`
+resource "rabbitmq_policy" "test" {
+    name = "test"
+    vhost = "test"
+    policy {
+        pattern = ".*"
+        priority = 0
+        apply_to = "%s"
+        definition = {
+            %s
+        }
+    }
+}`, applyTo, definition)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAcc.PreCheck(t) },
+		Providers: acceptance.TestAcc.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("queue", "max-length = 10"),
+				ExpectError: regexp.MustCompile(`expected policy.0.apply_to to be one of`),
+			},
+			{
+				Config:      config("queues", "max-length = \"ten\""),
+				ExpectError: regexp.MustCompile(`the value of the key 'max-length' must be an integer, got: ten`),
+			},
+			{
+				Config:      config("exchanges", "message-ttl = 1000"),
+				ExpectError: regexp.MustCompile(`the key 'message-ttl' applies to queues, it can't be used in a policy applied to exchanges`),
+			},
+			{
+				Config:      config("quorum_queues", "overflow = \"drop-tail\""),
+				ExpectError: regexp.MustCompile(`the value of the key 'overflow' must be one of \[drop-head, reject-publish, reject-publish-dlx\]`),
+			},
+		},
+	})
+}
+
 func testAccPolicyCheck(rn string, policy *rabbithole.Policy) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -46,7 +175,7 @@ func testAccPolicyCheck(rn string, policy *rabbithole.Policy) resource.TestCheck
 			return fmt.Errorf("policy id not set")
 		}
 
-		rmqc := acceptance.TestAcc.Provider.Meta().(*rabbithole.Client)
+		rmqc := acceptance.TestAcc.Provider.Meta().(*provider.Meta).Client
 		policyParts := strings.Split(rs.Primary.ID, "@")
 
 		policies, err := rmqc.ListPolicies()
@@ -65,9 +194,32 @@ func testAccPolicyCheck(rn string, policy *rabbithole.Policy) resource.TestCheck
 	}
 }
 
+func testAccPolicyCheckDefinition(rn string, expected map[string]interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		rmqc := acceptance.TestAcc.Provider.Meta().(*provider.Meta).Client
+		policyParts := strings.Split(rs.Primary.ID, "@")
+
+		policy, err := rmqc.GetPolicy(policyParts[1], policyParts[0])
+		if err != nil {
+			return fmt.Errorf("error retrieving policy: %s", err)
+		}
+
+		if !reflect.DeepEqual(map[string]interface{}(policy.Definition), expected) {
+			return fmt.Errorf("unexpected definition for policy %s: expected %#v, got %#v", rn, expected, policy.Definition)
+		}
+
+		return nil
+	}
+}
+
 func testAccPolicyCheckDestroy(policy *rabbithole.Policy) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := acceptance.TestAcc.Provider.Meta().(*rabbithole.Client)
+		rmqc := acceptance.TestAcc.Provider.Meta().(*provider.Meta).Client
 
 		policies, err := rmqc.ListPolicies()
 		if err != nil {
@@ -107,9 +259,8 @@ resource "rabbitmq_policy" "test" {
         priority = 0
         apply_to = "all"
         definition = {
-            ha-mode = "nodes"
-            ha-params = "a,b,c"
             max-length = 10000
+            message-ttl = 60000
         }
     }
 }`
@@ -137,7 +288,67 @@ resource "rabbitmq_policy" "test" {
         priority = 0
         apply_to = "all"
         definition = {
-            ha-mode = "all"
+            max-length = 20000
+        }
+    }
+}`
+
+const testAccPolicyConfig_classicMirroring = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_policy" "test" {
+    name = "test"
+    vhost = rabbitmq_vhost.test.name
+    policy {
+        pattern = ".*"
+        priority = 0
+        apply_to = "all"
+        definition = {
+            ha-mode = "nodes"
+            ha-params = "a,b,c"
+        }
+    }
+}`
+
+const testAccPolicyConfig_definitionJson = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_policy" "test" {
+    name = "test"
+    vhost = rabbitmq_vhost.test.name
+    policy {
+        pattern = ".*"
+        priority = 0
+        apply_to = "queues"
+        max_length = 1000
+        message_ttl = 60000
+        dead_letter_exchange = "dlx"
+        definition_json = jsonencode({
+            overflow = "reject-publish"
+            consumer-timeout = 3600000
+        })
+    }
+}`
+
+const testAccPolicyConfig_duplicateKey = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_policy" "test" {
+    name = "test"
+    vhost = rabbitmq_vhost.test.name
+    policy {
+        pattern = ".*"
+        priority = 0
+        apply_to = "queues"
+        max_length = 1000
+        definition = {
+            max-length = 10
         }
     }
 }`
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -12,10 +13,10 @@ import (
 
 func resourceQueue() *schema.Resource {
 	return &schema.Resource{
-		Description: "The rabbitmq_queue resource creates and manages a queue.",
-		Create:      CreateQueue,
-		Read:        ReadQueue,
-		Delete:      DeleteQueue,
+		Description:   "The rabbitmq_queue resource creates and manages a queue.",
+		CreateContext: withDeprecationWarnings(CreateQueue, resourceQueueDeprecations),
+		Read:          ReadQueue,
+		Delete:        DeleteQueue,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -91,7 +92,7 @@ func resourceQueue() *schema.Resource {
 }
 
 func CreateQueue(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name := d.Get("name").(string)
 	vhost := d.Get("vhost").(string)
@@ -132,8 +133,22 @@ func CreateQueue(d *schema.ResourceData, meta interface{}) error {
 	return ReadQueue(d, meta)
 }
 
+func resourceQueueDeprecations(d *schema.ResourceData, version string) diag.Diagnostics {
+	arguments := make(map[string]interface{})
+	if v, ok := d.Get("settings.0.arguments").(map[string]interface{}); ok {
+		arguments = v
+	}
+	if v, ok := d.Get("settings.0.arguments_json").(string); ok && v != "" {
+		if err := json.Unmarshal([]byte(v), &arguments); err != nil {
+			return nil
+		}
+	}
+
+	return queueDeprecations(d.Get("settings.0.durable").(bool), arguments, version)
+}
+
 func ReadQueue(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -200,7 +215,7 @@ func ReadQueue(d *schema.ResourceData, meta interface{}) error {
 }
 
 func DeleteQueue(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -1,17 +1,23 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"strconv"
+	"time"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceShovel() *schema.Resource {
 	return &schema.Resource{
-		Description: "The `rabbitmq_shovel` resource creates and manages a dynamic shovel.",
+		Description: "The `rabbitmq_shovel` resource creates and manages a dynamic shovel.\n-> **Note:** The shovel is redeclared in place when its settings change, except for a change of `source_protocol`, `destination_protocol`, `source_queue`, `source_exchange`, `source_exchange_key` or `source_address` which creates a new shovel.",
 		Create:      CreateShovel,
 		Update:      UpdateShovel,
 		Read:        ReadShovel,
@@ -19,215 +25,289 @@ func resourceShovel() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Description: "The shovel name.",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-			},
-			"vhost": {
-				Description: "The vhost to create the resource in.",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+		CustomizeDiff: customizeShovelDiff,
+		Timeouts:      shovelTimeouts(),
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceShovelV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceShovelStateUpgradeV0,
 			},
-			"info": {
-				Description: "The settings of the dynamic shovel. The structure is described below.",
-				Type:        schema.TypeList,
-				Required:    true,
-				MaxItems:    1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"ack_mode": {
-							Description: "Determines how the shovel should acknowledge messages. Possible values are `on-confirm`, `on-publish` and `no-ack`. Defaults to `on-confirm`.",
-							Type:        schema.TypeString,
-							Optional:    true,
-							ForceNew:    true,
-							Default:     "on-confirm",
-						},
-						"add_forward_headers": {
-							Description:   "Whether to add `x-shovelled` headers to shovelled messages.\n-> **Note:** Use `destination_add_forward_headers` instead.",
-							Type:          schema.TypeBool,
-							Optional:      true,
-							ForceNew:      true,
-							Default:       nil,
-							ConflictsWith: []string{"info.0.destination_add_forward_headers"},
-							Deprecated:    "use `destination_add_forward_headers` instead",
-						},
-						"delete_after": {
-							Description:   "Determines when (if ever) the shovel should delete itself. Possible values are `never`, `queue-length` or an integer.\n-> **Note:** Use `source_delete_after` instead.",
-							Type:          schema.TypeString,
-							Optional:      true,
-							ForceNew:      true,
-							Default:       nil,
-							ConflictsWith: []string{"info.0.source_delete_after"},
-							Deprecated:    "use `source_delete_after` instead",
-						},
-						"destination_add_forward_headers": {
-							Description:   "Whether to add _x-shovelled_ headers to shovelled messages.",
-							Type:          schema.TypeBool,
-							Optional:      true,
-							ForceNew:      true,
-							Default:       nil,
-							ConflictsWith: []string{"info.0.add_forward_headers"},
-						},
-						"destination_add_timestamp_header": {
-							Description: "Whether to add _x-shovelled-timestamp_ headers to shovelled messages. Defaults to `false`.",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							ForceNew:    true,
-							Default:     false,
-						},
-						"destination_address": {
-							Description: "**AMQP 1.0 specific parameter**: Destination link address.",
-							Type:        schema.TypeString,
-							Optional:    true,
-							ForceNew:    true,
-							Default:     nil,
-						},
-						"destination_application_properties": {
-							Description: "**AMQP 1.0 specific parameter**: A map of application properties to set when shovelling messages",
-							Type:        schema.TypeMap,
-							Optional:    true,
-							ForceNew:    true,
-							Default:     nil,
-						},
-						"destination_exchange": {
-							Description:   "The exchange to which messages should be published.\n~> **Note:** Either this or `destination_queue` must be specified but not both.",
-							Type:          schema.TypeString,
-							ConflictsWith: []string{"info.0.destination_queue"},
-							Optional:      true,
-							ForceNew:      true,
-							Default:       nil,
-						},
-						"destination_exchange_key": {
-							Description: "The routing key when using `destination_exchange`.",
-							Type:        schema.TypeString,
-							Optional:    true,
-							ForceNew:    true,
-							Default:     nil,
-						},
-						"destination_properties": {
-							Description: "**AMQP 1.0 specific parameter**: A map of properties to overwrite when shovelling messages.",
-							Type:        schema.TypeMap,
-							Optional:    true,
-							ForceNew:    true,
-							Default:     nil,
-						},
-						"destination_protocol": {
-							Description: "The protocol to use when connecting to the destination. Possible values are `amqp091` or `amqp10`. Defaults to `amqp091`.",
-							Type:        schema.TypeString,
-							Optional:    true,
-							ForceNew:    true,
-							Default:     "amqp091",
-						},
-						"destination_publish_properties": {
-							Description: "A map of properties to overwrite when shovelling messages.",
-							Type:        schema.TypeMap,
-							Optional:    true,
-							ForceNew:    true,
-							Default:     nil,
-						},
-						"destination_queue": {
-							Description:   "The queue to which messages should be published.\n~> **Note:** Either this or `destination_exchange` must be specified but not both.",
-							Type:          schema.TypeString,
-							ConflictsWith: []string{"info.0.destination_exchange"},
-							Default:       nil,
-							Optional:      true,
-							ForceNew:      true,
-						},
-						"destination_queue_arguments": {
-							Description: "A map of agurments to add into the queue.",
-							Type:        schema.TypeMap,
-							Optional:    true,
-							ForceNew:    true,
-							Default:     nil,
-						},
-						"destination_uri": {
-							Description: "The amqp uri for the destination.",
-							Type:        schema.TypeString,
-							Required:    true,
-							ForceNew:    true,
-							Sensitive:   false,
-						},
-						"prefetch_count": {
-							Description:   "The maximum number of unacknowledged messages copied over a shovel at any one time.\n-> **Note:** Use `source_prefetch_count` instead.",
-							Type:          schema.TypeInt,
-							Optional:      true,
-							ForceNew:      true,
-							ConflictsWith: []string{"info.0.source_prefetch_count"},
-							Deprecated:    "use `source_prefetch_count` instead",
-							Default:       nil,
-						},
-						"reconnect_delay": {
-							Description: "The duration in seconds to reconnect to a broker after disconnected. Defaults to `1`.",
-							Type:        schema.TypeInt,
-							Optional:    true,
-							ForceNew:    true,
-							Default:     1,
-						},
-						"source_address": {
-							Description: "**AMQP 1.0 specific parameter**: Source link address.",
-							Type:        schema.TypeString,
-							Optional:    true,
-							ForceNew:    true,
-							Default:     nil,
-						},
-						"source_delete_after": {
-							Description:   "Determines when (if ever) the shovel should delete itself. Possible values are `never`, `queue-length` or an integer.",
-							Type:          schema.TypeString,
-							Optional:      true,
-							ForceNew:      true,
-							Default:       nil,
-							ConflictsWith: []string{"info.0.delete_after"},
-						},
-						"source_exchange": {
-							Description:   "The exchange from which to consume.\n~> **Note:** Either this or `source_queue` must be specified but not both.",
-							Type:          schema.TypeString,
-							Default:       nil,
-							ConflictsWith: []string{"info.0.source_queue"},
-							Optional:      true,
-							ForceNew:      true,
-						},
-						"source_exchange_key": {
-							Description: "The routing key when using `source_exchange`.",
-							Type:        schema.TypeString,
-							Optional:    true,
-							ForceNew:    true,
-							Default:     nil,
-						},
-						"source_prefetch_count": {
-							Description:   "The maximum number of unacknowledged messages copied over a shovel at any one time.",
-							Type:          schema.TypeInt,
-							Optional:      true,
-							ForceNew:      true,
-							Default:       nil,
-							ConflictsWith: []string{"info.0.prefetch_count"},
-						},
-						"source_protocol": {
-							Description: "The protocol to use when connecting to the source. Possible values are `amqp091` or `amqp10`. Defaults to `amqp091`.",
-							Type:        schema.TypeString,
-							Optional:    true,
-							ForceNew:    true,
-							Default:     "amqp091",
-						},
-						"source_queue": {
-							Description:   "The queue from which to consume.\n~> **Note:** Either this or `source_exchange` must be specified but not both.",
-							Type:          schema.TypeString,
-							ConflictsWith: []string{"info.0.source_exchange"},
-							Default:       nil,
-							Optional:      true,
-							ForceNew:      true,
+		},
+
+		Schema: shovelSchema(),
+	}
+}
+
+func shovelTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(2 * time.Minute),
+		Update: schema.DefaultTimeout(2 * time.Minute),
+	}
+}
+
+func shovelSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Description: "The shovel name.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"vhost": {
+			Description: "The vhost to create the resource in.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"wait_for_running": {
+			Description: "Wait until the shovel is running when it's created or updated, the apply fails with the reason of a terminated shovel. The wait is limited by the `create` and `update` timeouts. Defaults to `false`.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"restart_triggers": {
+			Description: "A map of arbitrary values which restarts the shovel when it changes, e.g. to re-run a shovel with `source_delete_after`. A shovel which deleted itself is declared again.",
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"state": {
+			Description: "The state of the shovel: `starting`, `running`, `terminated`, or `completed` when a shovel with `source_delete_after` deleted itself.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"node": {
+			Description: "The node running the shovel.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"messages_transferred": {
+			Description: "The number of messages transferred by the shovel since it started, the last known value when the shovel deleted itself. Requires RabbitMQ 3.12 or later.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"last_error": {
+			Description: "The reason of the termination of the shovel, when its state is `terminated`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"info": {
+			Description: "The settings of the dynamic shovel. The structure is described below.",
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"ack_mode": {
+						Description:  "Determines how the shovel should acknowledge messages. Possible values are `on-confirm`, `on-publish` and `no-ack`. Defaults to `on-confirm`.",
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "on-confirm",
+						ValidateFunc: validation.StringInSlice([]string{"on-confirm", "on-publish", "no-ack"}, false),
+					},
+					"add_forward_headers": {
+						Description:   "Whether to add `x-shovelled` headers to shovelled messages.\n-> **Note:** Use `destination_add_forward_headers` instead.",
+						Type:          schema.TypeBool,
+						Optional:      true,
+						Default:       nil,
+						ConflictsWith: []string{"info.0.destination_add_forward_headers"},
+						Deprecated:    "use `destination_add_forward_headers` instead",
+					},
+					"delete_after": {
+						Description:   "Determines when (if ever) the shovel should delete itself. Possible values are `never`, `queue-length` or an integer.\n-> **Note:** Use `source_delete_after` instead.",
+						Type:          schema.TypeString,
+						Optional:      true,
+						Default:       nil,
+						ConflictsWith: []string{"info.0.source_delete_after"},
+						Deprecated:    "use `source_delete_after` instead",
+						ValidateFunc:  validateShovelDeleteAfter,
+					},
+					"destination_add_forward_headers": {
+						Description:   "Whether to add _x-shovelled_ headers to shovelled messages.",
+						Type:          schema.TypeBool,
+						Optional:      true,
+						Default:       nil,
+						ConflictsWith: []string{"info.0.add_forward_headers"},
+					},
+					"destination_add_timestamp_header": {
+						Description: "Whether to add _x-shovelled-timestamp_ headers to shovelled messages. Defaults to `false`.",
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+					},
+					"destination_address": {
+						Description: "**AMQP 1.0 specific parameter**: Destination link address.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     nil,
+					},
+					"destination_application_properties": {
+						Description: "**AMQP 1.0 specific parameter**: A map of application properties to set when shovelling messages",
+						Type:        schema.TypeMap,
+						Optional:    true,
+						Default:     nil,
+					},
+					"destination_exchange": {
+						Description:   "The exchange to which messages should be published.\n~> **Note:** Either this or `destination_queue` must be specified but not both.",
+						Type:          schema.TypeString,
+						ConflictsWith: []string{"info.0.destination_queue"},
+						Optional:      true,
+						Default:       nil,
+					},
+					"destination_exchange_key": {
+						Description: "The routing key when using `destination_exchange`.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     nil,
+					},
+					"destination_properties": {
+						Description: "**AMQP 1.0 specific parameter**: A map of properties to overwrite when shovelling messages.",
+						Type:        schema.TypeMap,
+						Optional:    true,
+						Default:     nil,
+					},
+					"destination_protocol": {
+						Description:  "The protocol to use when connecting to the destination. Possible values are `amqp091`, `amqp10` or `local` (a shovel within the cluster, RabbitMQ 4.2 or later). Defaults to `amqp091`.\n-> **Note:** The other fields of the destination are checked against its protocol.",
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "amqp091",
+						ValidateFunc: validation.StringInSlice(shovelProtocols, false),
+					},
+					"destination_publish_properties": {
+						Description: "A map of properties to overwrite when shovelling messages.",
+						Type:        schema.TypeMap,
+						Optional:    true,
+						Default:     nil,
+					},
+					"destination_queue": {
+						Description:   "The queue to which messages should be published.\n~> **Note:** Either this or `destination_exchange` must be specified but not both.",
+						Type:          schema.TypeString,
+						ConflictsWith: []string{"info.0.destination_exchange"},
+						Default:       nil,
+						Optional:      true,
+					},
+					"destination_queue_arguments": {
+						Description: "A map of agurments to add into the queue.",
+						Type:        schema.TypeMap,
+						Optional:    true,
+						Default:     nil,
+					},
+					"destination_connection": connectionSchema(
+						"The connection to the destination, assembled into an amqp uri by host. The structure is described below.\n~> **Note:** Exactly one of `destination_uris`, `destination_connection` or `destination_uri` must be specified.",
+						[]string{"info.0.destination_uri", "info.0.destination_uris", "info.0.destination_connection"},
+						false,
+					),
+					"destination_uri": {
+						Description:  "The amqp uri for the destination. The password of the uri is redacted in the state when it isn't the configured one, e.g. after an import.\n-> **Note:** Use `destination_uris` instead.",
+						Type:         schema.TypeString,
+						Optional:     true,
+						Computed:     true,
+						Sensitive:    true,
+						ExactlyOneOf: []string{"info.0.destination_uri", "info.0.destination_uris", "info.0.destination_connection"},
+						Deprecated:   "use `destination_uris` instead",
+					},
+					"destination_uris": {
+						Description: "The amqp uris for the destination, the shovel connects to one of them and fails over to the others. The passwords of the uris are redacted in the state when they aren't the configured ones, e.g. after an import.\n~> **Note:** Exactly one of `destination_uris`, `destination_connection` or `destination_uri` must be specified.",
+						Type:        schema.TypeList,
+						Optional:    true,
+						Computed:    true,
+						Sensitive:   true,
+						MinItems:    1,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
 						},
-						"source_uri": {
-							Description: "The amqp uri for the source.",
-							Type:        schema.TypeString,
-							Required:    true,
-							ForceNew:    true,
-							Sensitive:   false,
+						ExactlyOneOf: []string{"info.0.destination_uri", "info.0.destination_uris", "info.0.destination_connection"},
+					},
+					"prefetch_count": {
+						Description:   "The maximum number of unacknowledged messages copied over a shovel at any one time.\n-> **Note:** Use `source_prefetch_count` instead.",
+						Type:          schema.TypeInt,
+						Optional:      true,
+						ConflictsWith: []string{"info.0.source_prefetch_count"},
+						Deprecated:    "use `source_prefetch_count` instead",
+						Default:       nil,
+					},
+					"reconnect_delay": {
+						Description: "The duration in seconds to reconnect to a broker after disconnected. Defaults to `1`.",
+						Type:        schema.TypeInt,
+						Optional:    true,
+						Default:     1,
+					},
+					"source_address": {
+						Description: "**AMQP 1.0 specific parameter**: Source link address.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     nil,
+					},
+					"source_delete_after": {
+						Description:   "Determines when (if ever) the shovel should delete itself. Possible values are `never`, `queue-length` or an integer.",
+						Type:          schema.TypeString,
+						Optional:      true,
+						Default:       nil,
+						ConflictsWith: []string{"info.0.delete_after"},
+						ValidateFunc:  validateShovelDeleteAfter,
+					},
+					"source_exchange": {
+						Description:   "The exchange from which to consume.\n~> **Note:** Either this or `source_queue` must be specified but not both.",
+						Type:          schema.TypeString,
+						Default:       nil,
+						ConflictsWith: []string{"info.0.source_queue"},
+						Optional:      true,
+					},
+					"source_exchange_key": {
+						Description: "The routing key when using `source_exchange`.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     nil,
+					},
+					"source_prefetch_count": {
+						Description:   "The maximum number of unacknowledged messages copied over a shovel at any one time.",
+						Type:          schema.TypeInt,
+						Optional:      true,
+						Default:       nil,
+						ConflictsWith: []string{"info.0.prefetch_count"},
+					},
+					"source_protocol": {
+						Description:  "The protocol to use when connecting to the source. Possible values are `amqp091`, `amqp10` or `local` (a shovel within the cluster, RabbitMQ 4.2 or later). Defaults to `amqp091`.\n-> **Note:** The other fields of the source are checked against its protocol.",
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "amqp091",
+						ValidateFunc: validation.StringInSlice(shovelProtocols, false),
+					},
+					"source_queue": {
+						Description:   "The queue from which to consume.\n~> **Note:** Either this or `source_exchange` must be specified but not both.",
+						Type:          schema.TypeString,
+						ConflictsWith: []string{"info.0.source_exchange"},
+						Default:       nil,
+						Optional:      true,
+					},
+					"source_connection": connectionSchema(
+						"The connection to the source, assembled into an amqp uri by host. The structure is described below.\n~> **Note:** Exactly one of `source_uris`, `source_connection` or `source_uri` must be specified.",
+						[]string{"info.0.source_uri", "info.0.source_uris", "info.0.source_connection"},
+						false,
+					),
+					"source_uri": {
+						Description:  "The amqp uri for the source. The password of the uri is redacted in the state when it isn't the configured one, e.g. after an import.\n-> **Note:** Use `source_uris` instead.",
+						Type:         schema.TypeString,
+						Optional:     true,
+						Computed:     true,
+						Sensitive:    true,
+						ExactlyOneOf: []string{"info.0.source_uri", "info.0.source_uris", "info.0.source_connection"},
+						Deprecated:   "use `source_uris` instead",
+					},
+					"source_uris": {
+						Description: "The amqp uris for the source, the shovel connects to one of them and fails over to the others. The passwords of the uris are redacted in the state when they aren't the configured ones, e.g. after an import.\n~> **Note:** Exactly one of `source_uris`, `source_connection` or `source_uri` must be specified.",
+						Type:        schema.TypeList,
+						Optional:    true,
+						Computed:    true,
+						Sensitive:   true,
+						MinItems:    1,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
 						},
+						ExactlyOneOf: []string{"info.0.source_uri", "info.0.source_uris", "info.0.source_connection"},
 					},
 				},
 			},
@@ -236,7 +316,7 @@ func resourceShovel() *schema.Resource {
 }
 
 func CreateShovel(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	vhost := d.Get("vhost").(string)
 	shovelName := d.Get("name").(string)
@@ -247,7 +327,7 @@ func CreateShovel(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("unable to parse shovel info")
 	}
 
-	shovelDefinition := setShovelDefinition(shovelMap).(rabbithole.ShovelDefinition)
+	shovelDefinition := setShovelDefinition(shovelMap, getRawBlock(d.GetRawConfig(), "info")).(rabbithole.ShovelDefinition)
 
 	log.Printf("[DEBUG] RabbitMQ: Attempting to declare shovel %s in vhost %s", shovelName, vhost)
 	resp, err := rmqc.DeclareShovel(vhost, shovelName, shovelDefinition)
@@ -260,11 +340,17 @@ func CreateShovel(d *schema.ResourceData, meta interface{}) error {
 
 	d.SetId(shovelId)
 
+	if d.Get("wait_for_running").(bool) {
+		if err := waitForShovel(context.Background(), meta.(*Meta), vhost, shovelName, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
 	return ReadShovel(d, meta)
 }
 
 func ReadShovel(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -273,11 +359,27 @@ func ReadShovel(d *schema.ResourceData, meta interface{}) error {
 
 	shovelInfo, err := rmqc.GetShovel(vhost, name)
 	if err != nil {
+		var errorResponse rabbithole.ErrorResponse
+		if errors.As(err, &errorResponse) && errorResponse.StatusCode == 404 && isShovelSelfDeleting(d) {
+			// keep the shovel which deleted itself, it's declared again when its restart triggers change
+			log.Printf("[DEBUG] RabbitMQ: Shovel %s deleted itself", d.Id())
+			d.Set("state", shovelCompleted)
+			d.Set("node", "")
+			d.Set("last_error", "")
+			return nil
+		}
 		return checkDeleted(d, err)
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Shovel retrieved: Vhost: %#v, Name: %#v", vhost, name)
 
+	// the raw config isn't available on refresh, the raw state tells whether the connection blocks are used
+	rawInfo := getRawBlock(d.GetRawConfig(), "info")
+	if rawInfo.IsNull() {
+		rawInfo = getRawBlock(d.GetRawState(), "info")
+	}
+	current, _ := d.Get("info.0").(map[string]interface{})
+
 	info := make(map[string]interface{})
 	info["ack_mode"] = shovelInfo.Definition.AckMode
 	info["add_forward_headers"] = shovelInfo.Definition.AddForwardHeaders
@@ -293,9 +395,7 @@ func ReadShovel(d *schema.ResourceData, meta interface{}) error {
 	info["destination_publish_properties"] = shovelInfo.Definition.DestinationPublishProperties
 	info["destination_queue_arguments"] = shovelInfo.Definition.DestinationQueueArgs
 	info["destination_queue"] = shovelInfo.Definition.DestinationQueue
-	if len(shovelInfo.Definition.DestinationURI) > 0 {
-		info["destination_uri"] = shovelInfo.Definition.DestinationURI[0]
-	}
+	flattenShovelUris(info, "destination", shovelInfo.Definition.DestinationURI, rawInfo, current)
 	info["prefetch_count"] = shovelInfo.Definition.PrefetchCount
 	info["reconnect_delay"] = shovelInfo.Definition.ReconnectDelay
 	info["source_address"] = shovelInfo.Definition.SourceAddress
@@ -305,26 +405,45 @@ func ReadShovel(d *schema.ResourceData, meta interface{}) error {
 	info["source_prefetch_count"] = shovelInfo.Definition.SourcePrefetchCount
 	info["source_protocol"] = shovelInfo.Definition.SourceProtocol
 	info["source_queue"] = shovelInfo.Definition.SourceQueue
-	if len(shovelInfo.Definition.SourceURI) > 0 {
-		info["source_uri"] = shovelInfo.Definition.SourceURI[0]
-	}
+	flattenShovelUris(info, "source", shovelInfo.Definition.SourceURI, rawInfo, current)
 
 	d.Set("name", shovelInfo.Name)
 	d.Set("vhost", shovelInfo.Vhost)
 	d.Set("info", []map[string]interface{}{info})
+	// not stored by RabbitMQ, keep the configured value or the default on import
+	d.Set("wait_for_running", d.Get("wait_for_running").(bool))
+
+	status, err := getShovelStatus(meta.(*Meta), vhost, name)
+	if err != nil {
+		// the status is reported by the shovel management plugin
+		log.Printf("[WARN] RabbitMQ: Unable to get the status of shovel %s: %v", d.Id(), err)
+	} else {
+		state := shovelStarting
+		node := ""
+		if status != nil {
+			state = status.State
+			node = status.Node
+			if status.Forwarded != nil {
+				d.Set("messages_transferred", *status.Forwarded)
+			}
+		}
+		d.Set("state", state)
+		d.Set("node", node)
+		d.Set("last_error", status.lastError())
+	}
 
 	return nil
 }
 
 func UpdateShovel(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
 		return err
 	}
 
-	if d.HasChange("info") {
+	if isShovelRedeclared(d) {
 		_, newShovel := d.GetChange("info")
 
 		newShovelList := newShovel.([]interface{})
@@ -333,7 +452,7 @@ func UpdateShovel(d *schema.ResourceData, meta interface{}) error {
 			return fmt.Errorf("unable to parse shovel info")
 		}
 
-		shovelDefinition := setShovelDefinition(infoMap).(rabbithole.ShovelDefinition)
+		shovelDefinition := setShovelDefinition(infoMap, getRawBlock(d.GetRawConfig(), "info")).(rabbithole.ShovelDefinition)
 
 		log.Printf("[DEBUG] RabbitMQ: Attempting to declare shovel %s in vhost %s", name, vhost)
 		resp, err := rmqc.DeclareShovel(vhost, name, shovelDefinition)
@@ -341,12 +460,36 @@ func UpdateShovel(d *schema.ResourceData, meta interface{}) error {
 		if err != nil {
 			return err
 		}
+	} else if d.HasChange("restart_triggers") {
+		if err := restartShovel(meta.(*Meta), vhost, name); err != nil {
+			return err
+		}
+	} else {
+		return ReadShovel(d, meta)
+	}
+
+	if d.Get("wait_for_running").(bool) {
+		if err := waitForShovel(context.Background(), meta.(*Meta), vhost, name, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
 	}
+
 	return ReadShovel(d, meta)
 }
 
+// isShovelRedeclared returns whether the shovel is declared again on update: when its definition changed, or to
+// restart it when it deleted itself. The prior state is checked as the diff marks the state as computed.
+func isShovelRedeclared(d *schema.ResourceData) bool {
+	if d.HasChange("info") {
+		return true
+	}
+
+	state, _ := d.GetChange("state")
+	return d.HasChange("restart_triggers") && state.(string) == shovelCompleted
+}
+
 func DeleteShovel(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -358,6 +501,11 @@ func DeleteShovel(d *schema.ResourceData, meta interface{}) error {
 	resp, err := rmqc.DeleteShovel(vhost, name)
 	log.Printf("[DEBUG] RabbitMQ: shovel deletion response: %#v", resp)
 	if err != nil {
+		var errorResponse rabbithole.ErrorResponse
+		if errors.As(err, &errorResponse) && errorResponse.StatusCode == 404 {
+			// the shovel deleted itself
+			return nil
+		}
 		return err
 	}
 
@@ -368,7 +516,182 @@ func DeleteShovel(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func setShovelDefinition(shovelMap map[string]interface{}) interface{} {
+// a shovel deletes itself after transferring the messages of its source when 'source_delete_after' isn't 'never'
+func isShovelSelfDeleting(d *schema.ResourceData) bool {
+	for _, key := range []string{"info.0.source_delete_after", "info.0.delete_after"} {
+		if v := d.Get(key).(string); v != "" && v != "never" {
+			return true
+		}
+	}
+	return false
+}
+
+var shovelProtocols = []string{"amqp091", "amqp10", "local"}
+
+// The protocol 'local' is supported by the shovels since RabbitMQ 4.2
+const shovelLocalProtocolVersion = "4.2"
+
+// The info fields of a side of the shovel which are specific to the AMQP 1.0 protocol
+var shovelAmqp10Fields = map[string][]string{
+	"source":      {"source_address"},
+	"destination": {"destination_address", "destination_application_properties", "destination_properties"},
+}
+
+// The info fields of a side of the shovel which are specific to the AMQP 0.9.1 and local protocols
+var shovelAmqp091Fields = map[string][]string{
+	"source":      {"source_queue", "source_exchange", "source_exchange_key"},
+	"destination": {"destination_queue", "destination_exchange", "destination_exchange_key", "destination_publish_properties", "destination_queue_arguments"},
+}
+
+// check the fields of the source and the destination of a shovel against their protocol.
+// The protocols which are not known yet are not checked, nor the version of the broker when it's unknown.
+func checkShovelProtocols(rawInfo cty.Value, version string) error {
+	if rawInfo.IsNull() || !rawInfo.IsKnown() {
+		return nil
+	}
+
+	for _, side := range []string{"source", "destination"} {
+		protocol := "amqp091"
+		if isRawAttributeSet(rawInfo, side+"_protocol") {
+			v := rawInfo.GetAttr(side + "_protocol")
+			if !v.IsKnown() {
+				continue
+			}
+			protocol = v.AsString()
+		}
+
+		if protocol == "local" && version != "" && !isVersionAtLeast(version, shovelLocalProtocolVersion) {
+			return fmt.Errorf("the `local` protocol of the %s requires RabbitMQ %s or later, the broker runs RabbitMQ %s", side, shovelLocalProtocolVersion, version)
+		}
+
+		forbidden := shovelAmqp10Fields[side]
+		if protocol == "amqp10" {
+			forbidden = shovelAmqp091Fields[side]
+		}
+
+		for _, field := range forbidden {
+			if isRawAttributeSet(rawInfo, field) {
+				return fmt.Errorf("`%s` can't be used with the `%s` protocol of the %s", field, protocol, side)
+			}
+		}
+
+		if protocol == "amqp10" {
+			if !isRawAttributeSet(rawInfo, side+"_address") {
+				return fmt.Errorf("`%s_address` is required by the `amqp10` protocol of the %s", side, side)
+			}
+		} else if side == "source" && !isRawAttributeSet(rawInfo, "source_queue") && !isRawAttributeSet(rawInfo, "source_exchange") {
+			return fmt.Errorf("one of `source_queue` or `source_exchange` is required by the `%s` protocol of the source", protocol)
+		}
+	}
+
+	return nil
+}
+
+// validate a delete-after value: `never`, `queue-length` or a number of messages
+func validateShovelDeleteAfter(v interface{}, k string) (warns []string, errs []error) {
+	value, ok := v.(string)
+	if !ok {
+		errs = append(errs, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if value == "never" || value == "queue-length" {
+		return
+	}
+
+	if n, err := strconv.Atoi(value); err != nil || n < 0 {
+		errs = append(errs, fmt.Errorf("expected %s to be `never`, `queue-length` or a positive integer, got %s", k, value))
+	}
+
+	return
+}
+
+// The info fields whose change requires a new shovel: the protocols define the keys of the definition and
+// a shovel consuming from another source is another shovel. The other fields are updated by redeclaring the shovel.
+var shovelReplacementFields = []string{
+	"source_protocol",
+	"destination_protocol",
+	"source_queue",
+	"source_exchange",
+	"source_exchange_key",
+	"source_address",
+}
+
+func customizeShovelDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	version := ""
+	if m, ok := meta.(*Meta); ok {
+		version = m.brokerInfo().version
+	}
+	if err := checkShovelProtocols(getRawBlock(d.GetRawConfig(), "info"), version); err != nil {
+		return err
+	}
+
+	// the status of the shovels is reported by the management plugin of the shovels
+	plugins := []string{pluginShovel}
+	if d.Get("wait_for_running").(bool) {
+		plugins = append(plugins, pluginShovelManagement)
+	}
+	if err := checkResourcePlugins(meta, plugins...); err != nil {
+		return err
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	// the status of the shovel changes when it's declared or restarted
+	if d.HasChanges("info", "restart_triggers") {
+		for _, key := range []string{"state", "node", "last_error", "messages_transferred"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, field := range shovelReplacementFields {
+		key := "info.0." + field
+		if d.HasChange(key) {
+			if err := d.ForceNew(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// the uris configured for a side of the shovel, the uris of the state may have a redacted password and the computed
+// attributes are ignored
+func configuredShovelUris(rawInfo cty.Value, side string, shovelMap map[string]interface{}) []string {
+	if uris := getRawUris(rawInfo, side+"_uris"); len(uris) > 0 {
+		return uris
+	}
+	if uris := getRawUris(rawInfo, side+"_uri"); len(uris) > 0 {
+		return uris
+	}
+	if connection := getConnection(shovelMap[side+"_connection"]); connection != nil {
+		return expandConnectionUris(connection)
+	}
+	return nil
+}
+
+// set the uris of a side of the shovel, the passwords which aren't configured are redacted, and its connection block
+// when it's used by the configuration
+func flattenShovelUris(info map[string]interface{}, side string, uris []string, rawInfo cty.Value, current map[string]interface{}) {
+	if len(uris) == 0 {
+		return
+	}
+
+	state := stateUris(uris, configuredShovelUris(rawInfo, side, current))
+	info[side+"_uris"] = state
+	info[side+"_uri"] = state[0]
+
+	if !getRawBlock(rawInfo, side+"_connection").IsNull() {
+		info[side+"_connection"] = []map[string]interface{}{flattenConnectionUris(uris, getConnection(current[side+"_connection"]))}
+	}
+}
+
+func setShovelDefinition(shovelMap map[string]interface{}, rawInfo cty.Value) interface{} {
 	shovelDefinition := &rabbithole.ShovelDefinition{}
 
 	if v, ok := shovelMap["ack_mode"].(string); ok {
@@ -427,9 +750,7 @@ func setShovelDefinition(shovelMap map[string]interface{}) interface{} {
 		shovelDefinition.DestinationQueueArgs = v
 	}
 
-	if v, ok := shovelMap["destination_uri"].(string); ok {
-		shovelDefinition.DestinationURI = []string{v}
-	}
+	shovelDefinition.DestinationURI = configuredShovelUris(rawInfo, "destination", shovelMap)
 
 	if v, ok := shovelMap["prefetch_count"].(int); ok {
 		shovelDefinition.PrefetchCount = v
@@ -465,9 +786,153 @@ func setShovelDefinition(shovelMap map[string]interface{}) interface{} {
 		shovelDefinition.SourceQueue = v
 	}
 
-	if v, ok := shovelMap["source_uri"].(string); ok {
-		shovelDefinition.SourceURI = []string{v}
-	}
+	shovelDefinition.SourceURI = configuredShovelUris(rawInfo, "source", shovelMap)
 
 	return *shovelDefinition
 }
+
+// The version 0 of the schema had a single uri for the source and the destination
+func resourceShovelV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"vhost": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"info": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ack_mode": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"add_forward_headers": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"delete_after": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"destination_add_forward_headers": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"destination_add_timestamp_header": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"destination_address": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"destination_application_properties": {
+							Type:     schema.TypeMap,
+							Optional: true,
+						},
+						"destination_exchange": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"destination_exchange_key": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"destination_properties": {
+							Type:     schema.TypeMap,
+							Optional: true,
+						},
+						"destination_protocol": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"destination_publish_properties": {
+							Type:     schema.TypeMap,
+							Optional: true,
+						},
+						"destination_queue": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"destination_queue_arguments": {
+							Type:     schema.TypeMap,
+							Optional: true,
+						},
+						"destination_uri": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"prefetch_count": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"reconnect_delay": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"source_address": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"source_delete_after": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"source_exchange": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"source_exchange_key": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"source_prefetch_count": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"source_protocol": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"source_queue": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"source_uri": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// migrate the single uris into the lists of uris, the uris are the configured ones and are kept as is
+func resourceShovelStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	infos, _ := rawState["info"].([]interface{})
+	for _, i := range infos {
+		info, ok := i.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, side := range []string{"source", "destination"} {
+			if uri, ok := info[side+"_uri"].(string); ok && uri != "" {
+				info[side+"_uris"] = []interface{}{uri}
+			}
+		}
+	}
+
+	return rawState, nil
+}
@@ -8,11 +8,13 @@ import (
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/utils"
 )
 
 func resourceTopicPermissions() *schema.Resource {
 	return &schema.Resource{
-		Description: "The `rabbitmq_topic_permissions` resource creates and manages a user's set of topic permissions.",
+		Description: "The `rabbitmq_topic_permissions` resource creates and manages a user's set of topic permissions. The topic permissions of the exchanges which are not declared are removed.",
 		Create:      CreateTopicPermissions,
 		Update:      UpdateTopicPermissions,
 		Read:        ReadTopicPermissions,
@@ -50,15 +52,17 @@ func resourceTopicPermissions() *schema.Resource {
 						},
 
 						"write": {
-							Description: "The _write_ ACL.",
-							Type:        schema.TypeString,
-							Required:    true,
+							Description:  "The _write_ ACL.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: utils.ValidateErlangRegex,
 						},
 
 						"read": {
-							Description: "The _read_ ACL.",
-							Type:        schema.TypeString,
-							Required:    true,
+							Description:  "The _read_ ACL.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: utils.ValidateErlangRegex,
 						},
 					},
 				},
@@ -69,22 +73,13 @@ func resourceTopicPermissions() *schema.Resource {
 
 // CreateTopicPermissions for given exchanges
 func CreateTopicPermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	user := d.Get("user").(string)
 	vhost := d.Get("vhost").(string)
-	permsSet := d.Get("permissions").(*schema.Set)
-
-	for _, exchange := range permsSet.List() {
 
-		permsMap, ok := exchange.(map[string]interface{})
-		if !ok {
-			return fmt.Errorf("unable to parse permissions")
-		}
-
-		if err := setTopicPermissionsIn(rmqc, vhost, user, permsMap); err != nil {
-			return err
-		}
+	if err := putTopicPermissions(rmqc, vhost, user, d.Get("permissions").(*schema.Set)); err != nil {
+		return err
 	}
 
 	id := fmt.Sprintf("%s@%s", user, vhost)
@@ -95,7 +90,7 @@ func CreateTopicPermissions(d *schema.ResourceData, meta interface{}) error {
 
 // ReadTopicPermissions for the given ID
 func ReadTopicPermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	user, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -109,9 +104,16 @@ func ReadTopicPermissions(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[DEBUG] RabbitMQ: Topic permission retrieved for %s: %#v", d.Id(), userPerms)
 
-	d.Set("user", userPerms[0].User)
-	d.Set("vhost", userPerms[0].Vhost)
+	if len(userPerms) == 0 {
+		log.Printf("[WARN] RabbitMQ: no topic permissions found for %s, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("user", user)
+	d.Set("vhost", vhost)
 
+	// All the exchanges are refreshed, so the permissions set outside of Terraform are shown as a drift
 	perms := make([]map[string]interface{}, len(userPerms))
 	for i, perm := range userPerms {
 		p := make(map[string]interface{})
@@ -127,7 +129,7 @@ func ReadTopicPermissions(d *schema.ResourceData, meta interface{}) error {
 
 // UpdateTopicPermissions for given ID
 func UpdateTopicPermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	user, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -135,22 +137,9 @@ func UpdateTopicPermissions(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if d.HasChange("permissions") {
-		err = DeleteTopicPermissions(d, meta)
-		if err != nil {
+		if err := putTopicPermissions(rmqc, vhost, user, d.Get("permissions").(*schema.Set)); err != nil {
 			return err
 		}
-		_, newPerms := d.GetChange("permissions")
-		newPermsSet := newPerms.(*schema.Set)
-		for _, exchange := range newPermsSet.List() {
-			permsMap, ok := exchange.(map[string]interface{})
-			if !ok {
-				return fmt.Errorf("unable to parse permissions")
-			}
-
-			if err := setTopicPermissionsIn(rmqc, vhost, user, permsMap); err != nil {
-				return err
-			}
-		}
 	}
 
 	return ReadTopicPermissions(d, meta)
@@ -158,7 +147,7 @@ func UpdateTopicPermissions(d *schema.ResourceData, meta interface{}) error {
 
 // DeleteTopicPermissions for given ID
 func DeleteTopicPermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	user, vhost, err := parseResourceId(d)
 	if err != nil {
@@ -189,6 +178,48 @@ func DeleteTopicPermissions(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// set the permissions of the configured exchanges and remove the permissions of the other exchanges, which may exist
+// before the creation of the resource
+func putTopicPermissions(rmqc *rabbithole.Client, vhost string, user string, permsSet *schema.Set) error {
+	expected := make(map[string]bool)
+
+	for _, exchange := range permsSet.List() {
+		permsMap, ok := exchange.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unable to parse permissions")
+		}
+
+		if err := setTopicPermissionsIn(rmqc, vhost, user, permsMap); err != nil {
+			return err
+		}
+		expected[permsMap["exchange"].(string)] = true
+	}
+
+	userPerms, err := rmqc.GetTopicPermissionsIn(vhost, user)
+	if err != nil {
+		return err
+	}
+
+	// Only the permissions of the exchanges which are not in the configuration are removed
+	for _, perm := range userPerms {
+		if expected[perm.Exchange] {
+			continue
+		}
+
+		log.Printf("[DEBUG] RabbitMQ: Removing the topic permissions of %s@%s on exchange '%s'", user, vhost, perm.Exchange)
+		resp, err := rmqc.DeleteTopicPermissionsIn(vhost, user, perm.Exchange)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode >= 400 && resp.StatusCode != 404 {
+			return fmt.Errorf("error deleting RabbitMQ topic permission on exchange '%s': %s", perm.Exchange, resp.Status)
+		}
+	}
+
+	return nil
+}
+
 func setTopicPermissionsIn(rmqc *rabbithole.Client, vhost string, user string, permsMap map[string]interface{}) error {
 	perms := rabbithole.TopicPermissions{}
 
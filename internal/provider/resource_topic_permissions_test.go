@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"slices"
 	"strings"
 	"testing"
 
@@ -12,6 +13,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider"
 )
 
 func TestAccTopicPermissions(t *testing.T) {
@@ -45,6 +47,65 @@ func TestAccTopicPermissions(t *testing.T) {
 	})
 }
 
+func TestAccTopicPermissions_RemoveUnexpected(t *testing.T) {
+	var topicPermissionInfo rabbithole.TopicPermissionInfo
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAcc.PreCheck(t) },
+		Providers:    acceptance.TestAcc.Providers,
+		CheckDestroy: testAccTopicPermissionsCheckDestroy(&topicPermissionInfo),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTopicPermissionsConfigBasic,
+				Check: testAccTopicPermissionsCheck(
+					"rabbitmq_topic_permissions.test", &topicPermissionInfo,
+				),
+			},
+			{
+				PreConfig: func() {
+					rmqc := acceptance.TestAcc.Provider.Meta().(*provider.Meta).Client
+					if _, err := rmqc.UpdateTopicPermissionsIn("test", "mctest", rabbithole.TopicPermissions{Exchange: "unexpected", Write: ".*", Read: ".*"}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccTopicPermissionsConfigBasic,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccTopicPermissionsConfigBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("rabbitmq_topic_permissions.test", "permissions.#", "2"),
+					testAccTopicPermissionsCheckExchanges("test", "mctest", ".*", "test_remove"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTopicPermissions_InvalidRegex(t *testing.T) {
+
+	config := `
+resource "rabbitmq_topic_permissions" "test" {
+    user = "mctest"
+    vhost = "test"
+    permissions {
+        exchange = "amq.topic"
+        write = ".*"
+        read = "[a-z"
+    }
+}`
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAcc.PreCheck(t) },
+		Providers: acceptance.TestAcc.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`"\[a-z" is not a valid regular expression`),
+			},
+		},
+	})
+}
+
 func testAccTopicPermissionsCheck(rn string, topicPermissionInfo *rabbithole.TopicPermissionInfo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -56,7 +117,7 @@ func testAccTopicPermissionsCheck(rn string, topicPermissionInfo *rabbithole.Top
 			return fmt.Errorf("permission id not set")
 		}
 
-		rmqc := acceptance.TestAcc.Provider.Meta().(*rabbithole.Client)
+		rmqc := acceptance.TestAcc.Provider.Meta().(*provider.Meta).Client
 		perms, err := rmqc.ListTopicPermissions()
 		if err != nil {
 			return fmt.Errorf("error retrieving topic permissions: %s", err)
@@ -74,9 +135,32 @@ func testAccTopicPermissionsCheck(rn string, topicPermissionInfo *rabbithole.Top
 	}
 }
 
+func testAccTopicPermissionsCheckExchanges(vhost string, user string, exchanges ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := acceptance.TestAcc.Provider.Meta().(*provider.Meta).Client
+		perms, err := rmqc.GetTopicPermissionsIn(vhost, user)
+		if err != nil {
+			return fmt.Errorf("error retrieving topic permissions: %s", err)
+		}
+
+		actual := make([]string, len(perms))
+		for i, perm := range perms {
+			actual[i] = perm.Exchange
+		}
+
+		slices.Sort(exchanges)
+		slices.Sort(actual)
+		if !slices.Equal(exchanges, actual) {
+			return fmt.Errorf("unexpected topic permissions for user %s@%s: expected exchanges %v, got %v", user, vhost, exchanges, actual)
+		}
+
+		return nil
+	}
+}
+
 func testAccTopicPermissionsCheckDestroy(topicPermissionInfo *rabbithole.TopicPermissionInfo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := acceptance.TestAcc.Provider.Meta().(*rabbithole.Client)
+		rmqc := acceptance.TestAcc.Provider.Meta().(*provider.Meta).Client
 		perms, err := rmqc.ListTopicPermissions()
 		if err != nil {
 			return fmt.Errorf("error retrieving topic permissions: %s", err)
@@ -1,22 +1,33 @@
 package provider
 
 import (
+	"context"
 	"fmt"
-	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 )
 
 func resourceUser() *schema.Resource {
 	return &schema.Resource{
-		Description: "The `rabbitmq_user` resource creates and manages a user.",
-		Create:      CreateUser,
-		Update:      UpdateUser,
-		Read:        ReadUser,
-		Delete:      DeleteUser,
+		Description:   "The `rabbitmq_user` resource creates and manages a user.",
+		Create:        CreateUser,
+		Update:        UpdateUser,
+		Read:          ReadUser,
+		CustomizeDiff: customizeLimitsDiff("name", userLimitAttributes, "a `rabbitmq_user_limits` resource", currentUserLimits),
+		Delete:        DeleteUser,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: ImportUser,
+		},
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceUserV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceUserStateUpgradeV0,
+			},
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -39,23 +50,25 @@ func resourceUser() *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 			"max_connections": {
-				Description: "To limit how many connection a user can open.",
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    false,
+				Description:  "To limit how many connection a user can open. Use `-1` for unlimited, no limit is set when the attribute is omitted.\n~> **Note:** Don't use this attribute if the limit is managed by a `rabbitmq_user_limits` resource. A limit already set on the server is reported at plan, a limit set by a resource of the same apply only at apply.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     false,
+				ValidateFunc: validation.IntAtLeast(-1),
 			},
 			"max_channels": {
-				Description: "To limit how many channels, in total, a user can open.",
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    false,
+				Description:  "To limit how many channels, in total, a user can open. Use `-1` for unlimited, no limit is set when the attribute is omitted.\n~> **Note:** Don't use this attribute if the limit is managed by a `rabbitmq_user_limits` resource. A limit already set on the server is reported at plan, a limit set by a resource of the same apply only at apply.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     false,
+				ValidateFunc: validation.IntAtLeast(-1),
 			},
 		},
 	}
 }
 
 func CreateUser(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	name := d.Get("name").(string)
 
@@ -71,24 +84,9 @@ func CreateUser(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	limits := make(rabbithole.UserLimitsValues)
-
-	if v, ok := d.GetOk("max_connections"); ok {
-		if (len(v.(string))) > 0 {
-			v_int, err := strconv.Atoi(v.(string))
-			if err != nil {
-				return fmt.Errorf("error converting 'max_connections' to int: %#v", v)
-			}
-			limits["max-connections"] = v_int
-		}
-	}
-
-	if v, ok := d.GetOk("max_channels"); ok {
-		if (len(v.(string))) > 0 {
-			v_int, err := strconv.Atoi(v.(string))
-			if err != nil {
-				return fmt.Errorf("error converting 'max_channels' to int: %#v", v)
-			}
-			limits["max-channels"] = v_int
+	for _, l := range userLimitAttributes {
+		if v, ok := getRawInt(d.GetRawConfig(), l.attribute); ok {
+			limits[l.key] = v
 		}
 	}
 
@@ -109,7 +107,7 @@ func CreateUser(d *schema.ResourceData, meta interface{}) error {
 }
 
 func ReadUser(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	user, err := rmqc.GetUser(d.Id())
 	if err != nil {
@@ -129,71 +127,34 @@ func ReadUser(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	myUserLimits, err := rmqc.GetUserLimits(d.Id())
+	current, err := currentUserLimits(rmqc, d.Id())
 	if err != nil {
 		return checkDeleted(d, err)
 	}
 
-	if len(myUserLimits) > 0 {
-		if val, ok := myUserLimits[0].Value["max-connections"]; ok {
-			d.Set("max_connections", strconv.Itoa(val))
-		} else {
-			d.Set("max_connections", nil)
-		}
-
-		if val, ok := myUserLimits[0].Value["max-channels"]; ok {
-			d.Set("max_channels", strconv.Itoa(val))
-		} else {
-			d.Set("max_channels", nil)
-		}
-	}
+	// Only the limits managed by this resource are refreshed, the others may be managed by a 'rabbitmq_user_limits' resource
+	setManagedLimits(d, userLimitAttributes, current)
 
 	return nil
 }
 
 func UpdateUser(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 	name := d.Id()
 
 	userSettings := rabbithole.UserSettings{
 		Password: d.Get("password").(string),
 		Tags:     userTagsToString(d),
 	}
-	myUserLimits, err := rmqc.GetUserLimits(d.Id())
+	current, err := currentUserLimits(rmqc, name)
 	if err != nil {
 		return checkDeleted(d, err)
 	}
 
-	limits := make(rabbithole.UserLimitsValues)
-
-	if _, ok := d.GetOk("max_connections"); ok {
-		if d.HasChange("max_connections") {
-			_, newMaxConnections := d.GetChange("max_connections")
-
-			if v, ok := newMaxConnections.(string); ok {
-				limits["max-connections"], err = strconv.Atoi(v)
-				if err != nil {
-					return fmt.Errorf("error converting 'max_connections' to int: %#v", v)
-				}
-			}
-		} else {
-			limits["max-connections"] = myUserLimits[0].Value["max-connections"]
-		}
-	}
-
-	if _, ok := d.GetOk("max_channels"); ok {
-		if d.HasChange("max_channels") {
-			_, newMaxQueues := d.GetChange("max_channels")
-
-			if v, ok := newMaxQueues.(string); ok {
-				limits["max-channels"], err = strconv.Atoi(v)
-				if err != nil {
-					return fmt.Errorf("error converting 'max_channels' to int: %#v", v)
-				}
-			}
-		} else {
-			limits["max-channels"] = myUserLimits[0].Value["max-channels"]
-		}
+	// Only the limits which are removed from the configuration are deleted
+	limits, removed, conflict := planLimits(d, userLimitAttributes, current)
+	if conflict != nil {
+		return fmt.Errorf("error updating RabbitMQ user '%s': limit '%s' is already set, it may be managed by a `rabbitmq_user_limits` resource", name, conflict.key)
 	}
 
 	resp, err := rmqc.PutUser(name, userSettings)
@@ -201,9 +162,11 @@ func UpdateUser(d *schema.ResourceData, meta interface{}) error {
 		return failApiResponse(err, resp, "updating", "user")
 	}
 
-	resp, err = rmqc.DeleteUserLimits(name, rabbithole.UserLimits{"max-connections", "max-channels"})
-	if err != nil || resp.StatusCode >= 400 {
-		return failApiResponse(err, resp, "updating", "user limits")
+	if len(removed) > 0 {
+		resp, err = rmqc.DeleteUserLimits(name, removed)
+		if err != nil || resp.StatusCode >= 400 {
+			return failApiResponse(err, resp, "updating", "user limits")
+		}
 	}
 
 	if len(limits) > 0 {
@@ -217,15 +180,10 @@ func UpdateUser(d *schema.ResourceData, meta interface{}) error {
 }
 
 func DeleteUser(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 	name := d.Id()
 
-	resp, err := rmqc.DeleteUserLimits(name, rabbithole.UserLimits{"max-connections", "max-channels"})
-	if err != nil || (resp.StatusCode >= 400 && resp.StatusCode != 404) {
-		return failApiResponse(err, resp, "deleting", "user limits")
-	}
-
-	resp, err = rmqc.DeleteUser(name)
+	resp, err := rmqc.DeleteUser(name)
 	if err != nil || (resp.StatusCode >= 400 && resp.StatusCode != 404) {
 		return failApiResponse(err, resp, "deleting", "user")
 	}
@@ -233,6 +191,24 @@ func DeleteUser(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+func ImportUser(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	rmqc := meta.(*Meta).Client
+
+	current, err := currentUserLimits(rmqc, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	// The existing limits are imported to be managed by the resource
+	for _, l := range userLimitAttributes {
+		if val, ok := current[l.key]; ok {
+			d.Set(l.attribute, val)
+		}
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func userTagsToString(d *schema.ResourceData) rabbithole.UserTags {
 	tagList := rabbithole.UserTags{}
 
@@ -244,3 +220,38 @@ func userTagsToString(d *schema.ResourceData) rabbithole.UserTags {
 
 	return tagList
 }
+
+// The version 0 of the schema stored the limits as strings
+func resourceUserV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"tags": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"max_connections": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"max_channels": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceUserStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	return upgradeLimitsStateV0(rawState, userLimitAttributes)
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+)
+
+var userLimitAttributes = []limitAttribute{
+	{attribute: "max_connections", key: "max-connections"},
+	{attribute: "max_channels", key: "max-channels"},
+}
+
+func resourceUserLimits() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The `rabbitmq_user_limits` resource creates and manages the limits of a user.\n~> **Note:** A limit can't be managed by this resource and by the `rabbitmq_user` resource at the same time. A limit already set on the server is reported at plan, a limit set by a resource of the same apply only at apply.",
+		Create:        CreateUserLimits,
+		Update:        UpdateUserLimits,
+		Read:          ReadUserLimits,
+		CustomizeDiff: customizeLimitsDiff("user", userLimitAttributes, "a `rabbitmq_user` resource", currentUserLimits),
+		Delete:        DeleteUserLimits,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportUserLimits,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user": {
+				Description: "The name of the user.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"max_connections": {
+				Description:  "To limit how many connection the user can open. Use `-1` for unlimited.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(-1),
+				AtLeastOneOf: []string{"max_connections", "max_channels"},
+			},
+			"max_channels": {
+				Description:  "To limit how many channels, in total, the user can open. Use `-1` for unlimited.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(-1),
+				AtLeastOneOf: []string{"max_connections", "max_channels"},
+			},
+		},
+	}
+}
+
+func CreateUserLimits(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	user := d.Get("user").(string)
+
+	// Check if the user exists
+	if _, err := rmqc.GetUser(user); err != nil {
+		return fmt.Errorf("error creating RabbitMQ user limits for '%s': %v", user, err)
+	}
+
+	current, err := currentUserLimits(rmqc, user)
+	if err != nil {
+		return failApiResponse(err, nil, "creating", "user limits")
+	}
+
+	limits, _, conflict := planLimits(d, userLimitAttributes, current)
+	if conflict != nil {
+		return fmt.Errorf("error creating RabbitMQ user limits for '%s': limit '%s' is already set, it may be managed by the `%s` attribute of a `rabbitmq_user` resource", user, conflict.key, conflict.attribute)
+	}
+
+	resp, err := rmqc.PutUserLimits(user, limits)
+	if err != nil || resp.StatusCode >= 400 {
+		return failApiResponse(err, resp, "creating", "user limits")
+	}
+
+	d.SetId(user)
+	return ReadUserLimits(d, meta)
+}
+
+func ReadUserLimits(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	if _, err := rmqc.GetUser(d.Id()); err != nil {
+		return checkDeleted(d, err)
+	}
+
+	current, err := currentUserLimits(rmqc, d.Id())
+	if err != nil {
+		return checkDeleted(d, err)
+	}
+
+	d.Set("user", d.Id())
+
+	// Only the limits managed by this resource are refreshed
+	managed := setManagedLimits(d, userLimitAttributes, current)
+
+	if managed == 0 {
+		log.Printf("[WARN] RabbitMQ: no limits found for user '%s', removing it from state", d.Id())
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func UpdateUserLimits(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+	user := d.Id()
+
+	current, err := currentUserLimits(rmqc, user)
+	if err != nil {
+		return checkDeleted(d, err)
+	}
+
+	limits, removed, conflict := planLimits(d, userLimitAttributes, current)
+	if conflict != nil {
+		return fmt.Errorf("error updating RabbitMQ user limits for '%s': limit '%s' is already set, it may be managed by the `%s` attribute of a `rabbitmq_user` resource", user, conflict.key, conflict.attribute)
+	}
+
+	if len(removed) > 0 {
+		resp, err := rmqc.DeleteUserLimits(user, removed)
+		if err != nil || resp.StatusCode >= 400 {
+			return failApiResponse(err, resp, "updating", "user limits")
+		}
+	}
+
+	if len(limits) > 0 {
+		resp, err := rmqc.PutUserLimits(user, limits)
+		if err != nil || resp.StatusCode >= 400 {
+			return failApiResponse(err, resp, "updating", "user limits")
+		}
+	}
+
+	return ReadUserLimits(d, meta)
+}
+
+func DeleteUserLimits(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	var limits rabbithole.UserLimits
+	for _, l := range userLimitAttributes {
+		if _, ok := getRawInt(d.GetRawState(), l.attribute); ok {
+			limits = append(limits, l.key)
+		}
+	}
+
+	if len(limits) == 0 {
+		return nil
+	}
+
+	resp, err := rmqc.DeleteUserLimits(d.Id(), limits)
+	if err != nil || (resp.StatusCode >= 400 && resp.StatusCode != 404) {
+		return failApiResponse(err, resp, "deleting", "user limits")
+	}
+
+	return nil
+}
+
+func ImportUserLimits(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	rmqc := meta.(*Meta).Client
+
+	current, err := currentUserLimits(rmqc, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(current) == 0 {
+		return nil, fmt.Errorf("error importing RabbitMQ user limits: no limits found for user '%s'", d.Id())
+	}
+
+	for _, l := range userLimitAttributes {
+		if val, ok := current[l.key]; ok {
+			d.Set(l.attribute, val)
+		}
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func currentUserLimits(rmqc *rabbithole.Client, user string) (map[string]int, error) {
+	myUserLimits, err := rmqc.GetUserLimits(user)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(myUserLimits) == 0 {
+		return map[string]int{}, nil
+	}
+
+	return myUserLimits[0].Value, nil
+}
@@ -0,0 +1,109 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/acceptance"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/acceptance/check"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccUserLimits_Basic(t *testing.T) {
+	data := acceptance.BuildTestData("rabbitmq_user_limits", "test")
+	r := acceptance.UserLimitsResource{
+		User:           data.RandomString(),
+		Password:       data.RandomString(),
+		MaxConnections: data.RandomIntegerString(),
+		MaxChannels:    data.RandomIntegerString(),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAcc.PreCheck(t) },
+		Providers:    acceptance.TestAcc.Providers,
+		CheckDestroy: r.CheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: r.Create(data),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Exists(),
+					check.That(data.ResourceName).Key("id").MatchesOtherKey("user"),
+					check.That(data.ResourceName).Key("user").HasValue(r.User),
+					check.That(data.ResourceName).Key("max_connections").HasValue(r.MaxConnections),
+					check.That(data.ResourceName).Key("max_channels").HasValue(r.MaxChannels),
+					check.That(data.ResourceName).ExistsInRabbitMQ(r),
+				),
+			},
+			{
+				Config: r.Update(data),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Exists(),
+					check.That(data.ResourceName).Key("max_connections").HasValue(r.MaxConnections),
+					check.That(data.ResourceName).Key("max_channels").HasValue("-1"),
+					check.That(data.ResourceName).ExistsInRabbitMQ(r),
+				),
+			},
+			{
+				Config: r.RemoveOne(data),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Exists(),
+					check.That(data.ResourceName).Key("max_connections").HasValue(r.MaxConnections),
+					check.That(data.ResourceName).Key("max_channels").DoesNotExist(),
+					check.That(data.ResourceName).ExistsInRabbitMQ(r),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUserLimits_Import(t *testing.T) {
+	data := acceptance.BuildTestData("rabbitmq_user_limits", "test")
+	r := acceptance.UserLimitsResource{
+		User:           data.RandomString(),
+		Password:       data.RandomString(),
+		MaxConnections: data.RandomIntegerString(),
+		MaxChannels:    data.RandomIntegerString(),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAcc.PreCheck(t) },
+		Providers:    acceptance.TestAcc.Providers,
+		CheckDestroy: r.CheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: r.Create(data),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Exists(),
+					check.That(data.ResourceName).ExistsInRabbitMQ(r),
+				),
+			},
+			{
+				ResourceName:      data.ResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccUserLimits_ConflictWithUser(t *testing.T) {
+	data := acceptance.BuildTestData("rabbitmq_user_limits", "test")
+	r := acceptance.UserLimitsResource{
+		User:           data.RandomString(),
+		Password:       data.RandomString(),
+		MaxConnections: data.RandomIntegerString(),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAcc.PreCheck(t) },
+		Providers:    acceptance.TestAcc.Providers,
+		CheckDestroy: r.CheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      r.ConflictWithUser(data),
+				ExpectError: regexp.MustCompile("limit 'max-connections' is already set"),
+			},
+		},
+	})
+}
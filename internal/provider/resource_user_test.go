@@ -115,8 +115,8 @@ func TestAccUser_Optional(t *testing.T) {
 					check.That(data.ResourceName).Key("name").HasValue(r.Name),
 					check.That(data.ResourceName).Key("password").HasValue(r.Password),
 					check.That(data.ResourceName).Key("tags").Count(len(r.Tags)),
-					check.That(data.ResourceName).Key("max_connections").IsEmpty(),
-					check.That(data.ResourceName).Key("max_channels").IsEmpty(),
+					check.That(data.ResourceName).Key("max_connections").DoesNotExist(),
+					check.That(data.ResourceName).Key("max_channels").DoesNotExist(),
 					check.That(data.ResourceName).ExistsInRabbitMQ(r),
 				),
 			},
@@ -234,7 +234,7 @@ func TestAccUser_AlredayExist(t *testing.T) {
 	})
 }
 
-func TestAccUser_ErrorConvertingMaxConnections(t *testing.T) {
+func TestAccUser_InvalidMaxConnections(t *testing.T) {
 	data := acceptance.BuildTestData("rabbitmq_user", "test")
 	r := acceptance.UserResource{Name: data.RandomString(), Password: data.RandomString(), MaxConnections: data.RandomString()}
 
@@ -244,27 +244,27 @@ func TestAccUser_ErrorConvertingMaxConnections(t *testing.T) {
 		CheckDestroy: r.CheckDestroy(),
 		Steps: []resource.TestStep{
 			{
-				Config:      r.ErrorConvertingCreate(data),
-				ExpectError: regexp.MustCompile("error converting 'max_connections' to int"),
+				Config:      r.LimitsCreate(data),
+				ExpectError: regexp.MustCompile("a number is required"),
 			},
 			{
-				Config: r.ErrorConvertingUpdate(data, data.RandomIntegerString(), r.MaxChannels),
+				Config: r.LimitsUpdate(data, data.RandomIntegerString(), r.MaxChannels),
 				Check: resource.ComposeTestCheckFunc(
 					check.That(data.ResourceName).Exists(),
 					check.That(data.ResourceName).Key("max_connections").HasValue(r.MaxConnections),
-					check.That(data.ResourceName).Key("max_channels").IsEmpty(),
+					check.That(data.ResourceName).Key("max_channels").DoesNotExist(),
 					check.That(data.ResourceName).ExistsInRabbitMQ(r),
 				),
 			},
 			{
-				Config:      r.ErrorConvertingUpdate(data, data.RandomString(), r.MaxChannels),
-				ExpectError: regexp.MustCompile("error converting 'max_connections' to int"),
+				Config:      r.LimitsUpdate(data, "-5", r.MaxChannels),
+				ExpectError: regexp.MustCompile(`expected max_connections to be at least \(-1\)`),
 			},
 		},
 	})
 }
 
-func TestAccUser_ErrorConvertingMaxChannels(t *testing.T) {
+func TestAccUser_InvalidMaxChannels(t *testing.T) {
 	data := acceptance.BuildTestData("rabbitmq_user", "test")
 	r := acceptance.UserResource{Name: data.RandomString(), Password: data.RandomString(), MaxChannels: data.RandomString()}
 
@@ -274,21 +274,52 @@ func TestAccUser_ErrorConvertingMaxChannels(t *testing.T) {
 		CheckDestroy: r.CheckDestroy(),
 		Steps: []resource.TestStep{
 			{
-				Config:      r.ErrorConvertingCreate(data),
-				ExpectError: regexp.MustCompile("error converting 'max_channels' to int"),
+				Config:      r.LimitsCreate(data),
+				ExpectError: regexp.MustCompile("a number is required"),
 			},
 			{
-				Config: r.ErrorConvertingUpdate(data, r.MaxConnections, data.RandomIntegerString()),
+				Config: r.LimitsUpdate(data, r.MaxConnections, data.RandomIntegerString()),
 				Check: resource.ComposeTestCheckFunc(
 					check.That(data.ResourceName).Exists(),
 					check.That(data.ResourceName).Key("max_channels").HasValue(r.MaxChannels),
-					check.That(data.ResourceName).Key("max_connections").IsEmpty(),
+					check.That(data.ResourceName).Key("max_connections").DoesNotExist(),
 					check.That(data.ResourceName).ExistsInRabbitMQ(r),
 				),
 			},
 			{
-				Config:      r.ErrorConvertingUpdate(data, r.MaxConnections, data.RandomString()),
-				ExpectError: regexp.MustCompile("error converting 'max_channels' to int"),
+				Config:      r.LimitsUpdate(data, r.MaxConnections, "-5"),
+				ExpectError: regexp.MustCompile(`expected max_channels to be at least \(-1\)`),
+			},
+		},
+	})
+}
+
+func TestAccUser_RemoveOneLimit(t *testing.T) {
+	data := acceptance.BuildTestData("rabbitmq_user", "test")
+	r := acceptance.UserResource{Name: data.RandomString(), Password: data.RandomString(), MaxConnections: data.RandomIntegerString(), MaxChannels: data.RandomIntegerString()}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAcc.PreCheck(t) },
+		Providers:    acceptance.TestAcc.Providers,
+		CheckDestroy: r.CheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: r.LimitsCreate(data),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Exists(),
+					check.That(data.ResourceName).Key("max_connections").HasValue(r.MaxConnections),
+					check.That(data.ResourceName).Key("max_channels").HasValue(r.MaxChannels),
+					check.That(data.ResourceName).ExistsInRabbitMQ(r),
+				),
+			},
+			{
+				Config: r.LimitsUpdate(data, r.MaxConnections, ""),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Exists(),
+					check.That(data.ResourceName).Key("max_connections").HasValue(r.MaxConnections),
+					check.That(data.ResourceName).Key("max_channels").DoesNotExist(),
+					check.That(data.ResourceName).ExistsInRabbitMQ(r),
+				),
 			},
 		},
 	})
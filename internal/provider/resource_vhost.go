@@ -1,24 +1,37 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"strconv"
+	"net/url"
+	"strings"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceVhost() *schema.Resource {
 	return &schema.Resource{
-		Description: "The `rabbitmq_vhost` resource creates and manages a vhost.",
-		Create:      CreateVhost,
-		Read:        ReadVhost,
-		Delete:      DeleteVhost,
-		Update:      UpdateVhost,
+		Description:   "The `rabbitmq_vhost` resource creates and manages a vhost.",
+		Create:        CreateVhost,
+		Read:          ReadVhost,
+		CustomizeDiff: customizeLimitsDiff("name", vhostLimitAttributes, "a `rabbitmq_vhost_limits` resource", currentVhostLimits),
+		Delete:        DeleteVhost,
+		Update:        UpdateVhost,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: ImportVhost,
+		},
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceVhostV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceVhostStateUpgradeV0,
+			},
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -49,17 +62,39 @@ func resourceVhost() *schema.Resource {
 				ForceNew:    false,
 				Default:     false,
 			},
-			"max_connections": {
-				Description: "To limit the total number of concurrent client connections in vhost.",
-				Type:        schema.TypeString,
+			"tags": {
+				Description: "The tags of the vhost.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"protected_from_deletion": {
+				Description: "To protect the vhost from deletion by the broker. The vhost must be unprotected before being destroyed. Defaults to `false`.",
+				Type:        schema.TypeBool,
 				Optional:    true,
 				ForceNew:    false,
+				Default:     false,
 			},
-			"max_queues": {
-				Description: "To limit the total number of queues in vhost.",
-				Type:        schema.TypeString,
+			"prevent_destroy_if_not_empty": {
+				Description: "To refuse the destruction of the vhost while some of its queues still have messages. This check is done by the provider. Defaults to `false`.\n~> **Note:** The message counts come from the statistics of the management plugin, which are refreshed every collection interval (5 seconds by default). Messages published just before the destruction may not be counted yet.",
+				Type:        schema.TypeBool,
 				Optional:    true,
 				ForceNew:    false,
+				Default:     false,
+			},
+			"max_connections": {
+				Description:  "To limit the total number of concurrent client connections in vhost. Use `-1` for unlimited, no limit is set when the attribute is omitted.\n~> **Note:** Don't use this attribute if the limit is managed by a `rabbitmq_vhost_limits` resource. A limit already set on the server is reported at plan, a limit set by a resource of the same apply only at apply.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     false,
+				ValidateFunc: validation.IntAtLeast(-1),
+			},
+			"max_queues": {
+				Description:  "To limit the total number of queues in vhost. Use `-1` for unlimited, no limit is set when the attribute is omitted.\n~> **Note:** Don't use this attribute if the limit is managed by a `rabbitmq_vhost_limits` resource. A limit already set on the server is reported at plan, a limit set by a resource of the same apply only at apply.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     false,
+				ValidateFunc: validation.IntAtLeast(-1),
 			},
 		},
 	}
@@ -84,7 +119,7 @@ func validateDefaultQueueTypeAttribute(val interface{}, key string) (warns []str
 }
 
 func CreateVhost(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	vhost := d.Get("name").(string)
 
@@ -108,25 +143,12 @@ func CreateVhost(d *schema.ResourceData, meta interface{}) error {
 		settings.Tracing = v
 	}
 
-	limits := make(rabbithole.VhostLimitsValues)
+	settings.Tags = vhostTagsToString(d)
 
-	if v, ok := d.GetOk("max_connections"); ok {
-		if (len(v.(string))) > 0 {
-			v_int, err := strconv.Atoi(v.(string))
-			if err != nil {
-				return fmt.Errorf("error converting 'max_connections' to int: %#v", v)
-			}
-			limits["max-connections"] = v_int
-		}
-	}
-
-	if v, ok := d.GetOk("max_queues"); ok {
-		if (len(v.(string))) > 0 {
-			v_int, err := strconv.Atoi(v.(string))
-			if err != nil {
-				return fmt.Errorf("error converting 'max_queues' to int: %#v", v)
-			}
-			limits["max-queues"] = v_int
+	limits := make(rabbithole.VhostLimitsValues)
+	for _, l := range vhostLimitAttributes {
+		if v, ok := getRawInt(d.GetRawConfig(), l.attribute); ok {
+			limits[l.key] = v
 		}
 	}
 
@@ -135,6 +157,12 @@ func CreateVhost(d *schema.ResourceData, meta interface{}) error {
 		return failApiResponse(err, resp, "creating", "vhost")
 	}
 
+	if d.Get("protected_from_deletion").(bool) {
+		if err := setVhostDeletionProtection(meta.(*Meta), vhost, true); err != nil {
+			return failApiResponse(err, nil, "creating", "vhost deletion protection")
+		}
+	}
+
 	if len(limits) > 0 {
 		resp, err = rmqc.PutVhostLimits(vhost, limits)
 		if err != nil || resp.StatusCode >= 400 {
@@ -147,7 +175,7 @@ func CreateVhost(d *schema.ResourceData, meta interface{}) error {
 }
 
 func ReadVhost(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	vhost, err := rmqc.GetVhost(d.Id())
 	if err != nil {
@@ -163,45 +191,47 @@ func ReadVhost(d *schema.ResourceData, meta interface{}) error {
 		d.Set("description", vhost.Description)
 	}
 
-	myVhostLimits, err := rmqc.GetVhostLimits(d.Id())
+	var tagList []string
+	for _, v := range vhost.Tags {
+		if v != "" {
+			tagList = append(tagList, v)
+		}
+	}
+	d.Set("tags", tagList)
+
+	protected, err := isVhostProtectedFromDeletion(meta.(*Meta), d.Id())
 	if err != nil {
 		return checkDeleted(d, err)
 	}
+	d.Set("protected_from_deletion", protected)
 
-	if len(myVhostLimits) > 0 {
-		if val, ok := myVhostLimits[0].Value["max-connections"]; ok {
-			d.Set("max_connections", strconv.Itoa(val))
-		} else {
-			d.Set("max_connections", "") // set as unlimited
-		}
-
-		if val, ok := myVhostLimits[0].Value["max-queues"]; ok {
-			d.Set("max_queues", strconv.Itoa(val))
-		} else {
-			d.Set("max_queues", "") // set as unlimited
-		}
+	current, err := currentVhostLimits(rmqc, d.Id())
+	if err != nil {
+		return checkDeleted(d, err)
 	}
 
+	// Only the limits managed by this resource are refreshed, the others may be managed by a 'rabbitmq_vhost_limits' resource
+	setManagedLimits(d, vhostLimitAttributes, current)
+
 	d.Set("tracing", vhost.Tracing)
 
 	return nil
 }
 
 func UpdateVhost(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
 
 	vhost, err := rmqc.GetVhost(d.Id())
 	if err != nil {
 		return checkDeleted(d, err)
 	}
 
-	myVhostLimits, err := rmqc.GetVhostLimits(d.Id())
+	current, err := currentVhostLimits(rmqc, d.Id())
 	if err != nil {
 		return checkDeleted(d, err)
 	}
 
 	var settings rabbithole.VhostSettings
-	limits := make(rabbithole.VhostLimitsValues)
 
 	if d.HasChange("description") {
 		_, newDescription := d.GetChange("description")
@@ -233,34 +263,12 @@ func UpdateVhost(d *schema.ResourceData, meta interface{}) error {
 		settings.Tracing = vhost.Tracing
 	}
 
-	if _, ok := d.GetOk("max_connections"); ok {
-		if d.HasChange("max_connections") {
-			_, newMaxConnections := d.GetChange("max_connections")
-
-			if v, ok := newMaxConnections.(string); ok {
-				limits["max-connections"], err = strconv.Atoi(v)
-				if err != nil {
-					return fmt.Errorf("error converting 'max_connections' to int: %#v", v)
-				}
-			}
-		} else {
-			limits["max-connections"] = myVhostLimits[0].Value["max-connections"]
-		}
-	}
-
-	if _, ok := d.GetOk("max_queues"); ok {
-		if d.HasChange("max_queues") {
-			_, newMaxQueues := d.GetChange("max_queues")
+	settings.Tags = vhostTagsToString(d)
 
-			if v, ok := newMaxQueues.(string); ok {
-				limits["max-queues"], err = strconv.Atoi(v)
-				if err != nil {
-					return fmt.Errorf("error converting 'max_queues' to int: %#v", v)
-				}
-			}
-		} else {
-			limits["max-queues"] = myVhostLimits[0].Value["max-queues"]
-		}
+	// Only the limits which are removed from the configuration are deleted
+	limits, removed, conflict := planLimits(d, vhostLimitAttributes, current)
+	if conflict != nil {
+		return fmt.Errorf("error updating RabbitMQ vhost '%s': limit '%s' is already set, it may be managed by a `rabbitmq_vhost_limits` resource", vhost.Name, conflict.key)
 	}
 
 	resp, err := rmqc.PutVhost(vhost.Name, settings)
@@ -269,9 +277,17 @@ func UpdateVhost(d *schema.ResourceData, meta interface{}) error {
 		return failApiResponse(err, resp, "updating", "vhost")
 	}
 
-	resp, err = rmqc.DeleteVhostLimits(vhost.Name, rabbithole.VhostLimits{"max-connections", "max-queues"})
-	if err != nil || resp.StatusCode >= 400 {
-		return failApiResponse(err, resp, "updating", "vhost limits")
+	if d.HasChange("protected_from_deletion") {
+		if err := setVhostDeletionProtection(meta.(*Meta), vhost.Name, d.Get("protected_from_deletion").(bool)); err != nil {
+			return failApiResponse(err, nil, "updating", "vhost deletion protection")
+		}
+	}
+
+	if len(removed) > 0 {
+		resp, err = rmqc.DeleteVhostLimits(vhost.Name, removed)
+		if err != nil || resp.StatusCode >= 400 {
+			return failApiResponse(err, resp, "updating", "vhost limits")
+		}
 	}
 
 	if len(limits) > 0 {
@@ -285,12 +301,133 @@ func UpdateVhost(d *schema.ResourceData, meta interface{}) error {
 }
 
 func DeleteVhost(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*Meta).Client
+
+	if d.Get("protected_from_deletion").(bool) {
+		return fmt.Errorf("error deleting RabbitMQ vhost '%s': the vhost is protected from deletion, set 'protected_from_deletion' to false before destroying it", d.Id())
+	}
+
+	// Deleting a vhost deletes all its queues and their messages
+	if d.Get("prevent_destroy_if_not_empty").(bool) {
+		queues, err := rmqc.ListQueuesIn(d.Id())
+		if err != nil {
+			return checkDeleted(d, err)
+		}
+
+		// The counts come from the management statistics, so they can lag behind
+		// the queues by up to one collection interval
+		var notEmpty []string
+		for _, q := range queues {
+			if q.Messages > 0 {
+				notEmpty = append(notEmpty, fmt.Sprintf("'%s' (%d messages)", q.Name, q.Messages))
+			}
+		}
+
+		if len(notEmpty) > 0 {
+			return fmt.Errorf("error deleting RabbitMQ vhost '%s': the vhost is not empty, queues with messages: %s", d.Id(), strings.Join(notEmpty, ", "))
+		}
+	}
 
 	resp, err := rmqc.DeleteVhost(d.Id())
 	if err != nil || (resp.StatusCode >= 400 && resp.StatusCode != 404) {
-		return failApiResponse(err, resp, "deleting", "vhost limits")
+		return failApiResponse(err, resp, "deleting", "vhost")
 	}
 
 	return nil
 }
+
+func ImportVhost(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	rmqc := meta.(*Meta).Client
+
+	current, err := currentVhostLimits(rmqc, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	// The existing limits are imported to be managed by the resource
+	for _, l := range vhostLimitAttributes {
+		if val, ok := current[l.key]; ok {
+			d.Set(l.attribute, val)
+		}
+	}
+
+	d.Set("prevent_destroy_if_not_empty", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func vhostTagsToString(d *schema.ResourceData) rabbithole.VhostTags {
+	tagList := rabbithole.VhostTags{}
+
+	for _, v := range d.Get("tags").([]interface{}) {
+		if tag, ok := v.(string); ok {
+			tagList = append(tagList, tag)
+		}
+	}
+
+	return tagList
+}
+
+// The deletion protection of a vhost is a metadata exposed by RabbitMQ 4.1 and later
+func isVhostProtectedFromDeletion(m *Meta, vhost string) (bool, error) {
+	var rec struct {
+		ProtectedFromDeletion bool `json:"protected_from_deletion"`
+		Metadata              struct {
+			ProtectedFromDeletion bool `json:"protected_from_deletion"`
+		} `json:"metadata"`
+	}
+
+	if err := apiRequest(m, "GET", "vhosts/"+url.PathEscape(vhost), nil, &rec); err != nil {
+		return false, err
+	}
+
+	return rec.ProtectedFromDeletion || rec.Metadata.ProtectedFromDeletion, nil
+}
+
+func setVhostDeletionProtection(m *Meta, vhost string, protected bool) error {
+	method := "DELETE"
+	if protected {
+		method = "POST"
+	}
+
+	return apiRequest(m, method, "vhosts/"+url.PathEscape(vhost)+"/deletion/protection", nil, nil)
+}
+
+// The version 0 of the schema stored the limits as strings
+func resourceVhostV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"default_queue_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "classic",
+			},
+			"tracing": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"max_connections": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"max_queues": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceVhostStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	return upgradeLimitsStateV0(rawState, vhostLimitAttributes)
+}
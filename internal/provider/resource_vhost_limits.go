@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+)
+
+var vhostLimitAttributes = []limitAttribute{
+	{attribute: "max_connections", key: "max-connections"},
+	{attribute: "max_queues", key: "max-queues"},
+}
+
+func resourceVhostLimits() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The `rabbitmq_vhost_limits` resource creates and manages the limits of a vhost.\n~> **Note:** A limit can't be managed by this resource and by the `rabbitmq_vhost` resource at the same time. A limit already set on the server is reported at plan, a limit set by a resource of the same apply only at apply.",
+		Create:        CreateVhostLimits,
+		Update:        UpdateVhostLimits,
+		Read:          ReadVhostLimits,
+		CustomizeDiff: customizeLimitsDiff("vhost", vhostLimitAttributes, "a `rabbitmq_vhost` resource", currentVhostLimits),
+		Delete:        DeleteVhostLimits,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportVhostLimits,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"vhost": {
+				Description: "The name of the vhost.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"max_connections": {
+				Description:  "To limit the total number of concurrent client connections in the vhost. Use `-1` for unlimited.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(-1),
+				AtLeastOneOf: []string{"max_connections", "max_queues"},
+			},
+			"max_queues": {
+				Description:  "To limit the total number of queues in the vhost. Use `-1` for unlimited.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(-1),
+				AtLeastOneOf: []string{"max_connections", "max_queues"},
+			},
+		},
+	}
+}
+
+func CreateVhostLimits(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	vhost := d.Get("vhost").(string)
+
+	// Check if the vhost exists
+	if _, err := rmqc.GetVhost(vhost); err != nil {
+		return fmt.Errorf("error creating RabbitMQ vhost limits for '%s': %v", vhost, err)
+	}
+
+	current, err := currentVhostLimits(rmqc, vhost)
+	if err != nil {
+		return failApiResponse(err, nil, "creating", "vhost limits")
+	}
+
+	limits, _, conflict := planLimits(d, vhostLimitAttributes, current)
+	if conflict != nil {
+		return fmt.Errorf("error creating RabbitMQ vhost limits for '%s': limit '%s' is already set, it may be managed by the `%s` attribute of a `rabbitmq_vhost` resource", vhost, conflict.key, conflict.attribute)
+	}
+
+	resp, err := rmqc.PutVhostLimits(vhost, limits)
+	if err != nil || resp.StatusCode >= 400 {
+		return failApiResponse(err, resp, "creating", "vhost limits")
+	}
+
+	d.SetId(vhost)
+	return ReadVhostLimits(d, meta)
+}
+
+func ReadVhostLimits(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	if _, err := rmqc.GetVhost(d.Id()); err != nil {
+		return checkDeleted(d, err)
+	}
+
+	current, err := currentVhostLimits(rmqc, d.Id())
+	if err != nil {
+		return checkDeleted(d, err)
+	}
+
+	d.Set("vhost", d.Id())
+
+	// Only the limits managed by this resource are refreshed
+	managed := setManagedLimits(d, vhostLimitAttributes, current)
+
+	if managed == 0 {
+		log.Printf("[WARN] RabbitMQ: no limits found for vhost '%s', removing it from state", d.Id())
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func UpdateVhostLimits(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+	vhost := d.Id()
+
+	current, err := currentVhostLimits(rmqc, vhost)
+	if err != nil {
+		return checkDeleted(d, err)
+	}
+
+	limits, removed, conflict := planLimits(d, vhostLimitAttributes, current)
+	if conflict != nil {
+		return fmt.Errorf("error updating RabbitMQ vhost limits for '%s': limit '%s' is already set, it may be managed by the `%s` attribute of a `rabbitmq_vhost` resource", vhost, conflict.key, conflict.attribute)
+	}
+
+	if len(removed) > 0 {
+		resp, err := rmqc.DeleteVhostLimits(vhost, removed)
+		if err != nil || resp.StatusCode >= 400 {
+			return failApiResponse(err, resp, "updating", "vhost limits")
+		}
+	}
+
+	if len(limits) > 0 {
+		resp, err := rmqc.PutVhostLimits(vhost, limits)
+		if err != nil || resp.StatusCode >= 400 {
+			return failApiResponse(err, resp, "updating", "vhost limits")
+		}
+	}
+
+	return ReadVhostLimits(d, meta)
+}
+
+func DeleteVhostLimits(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	var limits rabbithole.VhostLimits
+	for _, l := range vhostLimitAttributes {
+		if _, ok := getRawInt(d.GetRawState(), l.attribute); ok {
+			limits = append(limits, l.key)
+		}
+	}
+
+	if len(limits) == 0 {
+		return nil
+	}
+
+	resp, err := rmqc.DeleteVhostLimits(d.Id(), limits)
+	if err != nil || (resp.StatusCode >= 400 && resp.StatusCode != 404) {
+		return failApiResponse(err, resp, "deleting", "vhost limits")
+	}
+
+	return nil
+}
+
+func ImportVhostLimits(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	rmqc := meta.(*Meta).Client
+
+	current, err := currentVhostLimits(rmqc, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(current) == 0 {
+		return nil, fmt.Errorf("error importing RabbitMQ vhost limits: no limits found for vhost '%s'", d.Id())
+	}
+
+	for _, l := range vhostLimitAttributes {
+		if val, ok := current[l.key]; ok {
+			d.Set(l.attribute, val)
+		}
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func currentVhostLimits(rmqc *rabbithole.Client, vhost string) (map[string]int, error) {
+	myVhostLimits, err := rmqc.GetVhostLimits(vhost)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(myVhostLimits) == 0 {
+		return map[string]int{}, nil
+	}
+
+	return myVhostLimits[0].Value, nil
+}
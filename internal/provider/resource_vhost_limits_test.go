@@ -0,0 +1,106 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/acceptance"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/acceptance/check"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVhostLimits_Basic(t *testing.T) {
+	data := acceptance.BuildTestData("rabbitmq_vhost_limits", "test")
+	r := acceptance.VhostLimitsResource{
+		Vhost:          data.RandomString(),
+		MaxConnections: data.RandomIntegerString(),
+		MaxQueues:      data.RandomIntegerString(),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAcc.PreCheck(t) },
+		Providers:    acceptance.TestAcc.Providers,
+		CheckDestroy: r.CheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: r.Create(data),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Exists(),
+					check.That(data.ResourceName).Key("id").MatchesOtherKey("vhost"),
+					check.That(data.ResourceName).Key("vhost").HasValue(r.Vhost),
+					check.That(data.ResourceName).Key("max_connections").HasValue(r.MaxConnections),
+					check.That(data.ResourceName).Key("max_queues").HasValue(r.MaxQueues),
+					check.That(data.ResourceName).ExistsInRabbitMQ(r),
+				),
+			},
+			{
+				Config: r.Update(data),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Exists(),
+					check.That(data.ResourceName).Key("max_connections").HasValue(r.MaxConnections),
+					check.That(data.ResourceName).Key("max_queues").HasValue("-1"),
+					check.That(data.ResourceName).ExistsInRabbitMQ(r),
+				),
+			},
+			{
+				Config: r.RemoveOne(data),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Exists(),
+					check.That(data.ResourceName).Key("max_connections").HasValue(r.MaxConnections),
+					check.That(data.ResourceName).Key("max_queues").DoesNotExist(),
+					check.That(data.ResourceName).ExistsInRabbitMQ(r),
+				),
+			},
+		},
+	})
+}
+
+func TestAccVhostLimits_Import(t *testing.T) {
+	data := acceptance.BuildTestData("rabbitmq_vhost_limits", "test")
+	r := acceptance.VhostLimitsResource{
+		Vhost:          data.RandomString(),
+		MaxConnections: data.RandomIntegerString(),
+		MaxQueues:      data.RandomIntegerString(),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAcc.PreCheck(t) },
+		Providers:    acceptance.TestAcc.Providers,
+		CheckDestroy: r.CheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: r.Create(data),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Exists(),
+					check.That(data.ResourceName).ExistsInRabbitMQ(r),
+				),
+			},
+			{
+				ResourceName:      data.ResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccVhostLimits_ConflictWithVhost(t *testing.T) {
+	data := acceptance.BuildTestData("rabbitmq_vhost_limits", "test")
+	r := acceptance.VhostLimitsResource{
+		Vhost:          data.RandomString(),
+		MaxConnections: data.RandomIntegerString(),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAcc.PreCheck(t) },
+		Providers:    acceptance.TestAcc.Providers,
+		CheckDestroy: r.CheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      r.ConflictWithVhost(data),
+				ExpectError: regexp.MustCompile("limit 'max-connections' is already set"),
+			},
+		},
+	})
+}
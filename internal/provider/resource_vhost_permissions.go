@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/utils"
+)
+
+func resourceVhostPermissions() *schema.Resource {
+	return &schema.Resource{
+		Description: "The `rabbitmq_vhost_permissions` resource manages authoritatively the permissions of all the users in a vhost. The permissions of the users which are not declared are revoked.\n~> **Note:** Don't use this resource with `rabbitmq_permissions` resources in the same vhost.",
+		Create:      CreateVhostPermissions,
+		Update:      UpdateVhostPermissions,
+		Read:        ReadVhostPermissions,
+		Delete:      DeleteVhostPermissions,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportVhostPermissions,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"vhost": {
+				Description: "The vhost to manage the permissions in.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"permissions": {
+				Description: "The permissions of a user in the vhost. The structure is described below.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user": {
+							Description: "The user to apply the permissions to.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+
+						"configure": {
+							Description:  "The _configure_ ACL",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: utils.ValidateErlangRegex,
+						},
+
+						"write": {
+							Description:  "The _write_ ACL",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: utils.ValidateErlangRegex,
+						},
+
+						"read": {
+							Description:  "The _read_ ACL",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: utils.ValidateErlangRegex,
+						},
+					},
+				},
+			},
+
+			"ignore_users": {
+				Description: "The users whose permissions are not managed by the resource (e.g. system accounts).",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func CreateVhostPermissions(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	vhost := d.Get("vhost").(string)
+
+	// Check if the vhost exists
+	if _, err := rmqc.GetVhost(vhost); err != nil {
+		return fmt.Errorf("error creating RabbitMQ vhost permissions for '%s': %v", vhost, err)
+	}
+
+	if err := applyVhostPermissions(rmqc, d, vhost); err != nil {
+		return err
+	}
+
+	d.SetId(vhost)
+
+	return ReadVhostPermissions(d, meta)
+}
+
+func ReadVhostPermissions(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+	vhost := d.Id()
+
+	if _, err := rmqc.GetVhost(vhost); err != nil {
+		return checkDeleted(d, err)
+	}
+
+	vhostPerms, err := listPermissionsIn(rmqc, vhost)
+	if err != nil {
+		return checkDeleted(d, err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Permissions retrieved for vhost %s: %#v", vhost, vhostPerms)
+
+	ignored := ignoredUsers(d)
+
+	// The permissions of the unexpected users are shown as a drift
+	perms := make([]map[string]interface{}, 0, len(vhostPerms))
+	for _, p := range vhostPerms {
+		if ignored[p.User] {
+			continue
+		}
+		perms = append(perms, map[string]interface{}{
+			"user":      p.User,
+			"configure": p.Configure,
+			"write":     p.Write,
+			"read":      p.Read,
+		})
+	}
+
+	d.Set("vhost", vhost)
+	d.Set("permissions", perms)
+
+	return nil
+}
+
+// The ignored users aren't stored by RabbitMQ, they can be given with the vhost: '<user>,<user>@<vhost>'
+func ImportVhostPermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if !strings.Contains(d.Id(), "@") {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	users, vhost, err := parseId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(vhost)
+	d.Set("ignore_users", strings.Split(users, ","))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func UpdateVhostPermissions(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+
+	if err := applyVhostPermissions(rmqc, d, d.Id()); err != nil {
+		return err
+	}
+
+	return ReadVhostPermissions(d, meta)
+}
+
+func DeleteVhostPermissions(d *schema.ResourceData, meta interface{}) error {
+	rmqc := meta.(*Meta).Client
+	vhost := d.Id()
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to delete permissions of vhost %s", vhost)
+
+	for _, v := range d.Get("permissions").(*schema.Set).List() {
+		user := v.(map[string]interface{})["user"].(string)
+
+		resp, err := rmqc.ClearPermissionsIn(vhost, user)
+		log.Printf("[DEBUG] RabbitMQ: Permission delete response: %#v", resp)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode >= 400 && resp.StatusCode != 404 {
+			return fmt.Errorf("error deleting RabbitMQ permission of '%s' in vhost '%s': %s", user, vhost, resp.Status)
+		}
+	}
+
+	return nil
+}
+
+// set the permissions of the configured users and revoke the permissions of the other users, except the ignored ones
+func applyVhostPermissions(rmqc *rabbithole.Client, d *schema.ResourceData, vhost string) error {
+	ignored := ignoredUsers(d)
+	expected := make(map[string]bool)
+
+	for _, v := range d.Get("permissions").(*schema.Set).List() {
+		permsMap := v.(map[string]interface{})
+		user := permsMap["user"].(string)
+
+		if ignored[user] {
+			return fmt.Errorf("error setting RabbitMQ permissions in vhost '%s': user '%s' can't be declared in 'permissions' and in 'ignore_users'", vhost, user)
+		}
+		if expected[user] {
+			return fmt.Errorf("error setting RabbitMQ permissions in vhost '%s': user '%s' is declared several times", vhost, user)
+		}
+		expected[user] = true
+
+		if err := setPermissionsIn(rmqc, vhost, user, permsMap); err != nil {
+			return err
+		}
+	}
+
+	vhostPerms, err := listPermissionsIn(rmqc, vhost)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range vhostPerms {
+		if expected[p.User] || ignored[p.User] {
+			continue
+		}
+
+		log.Printf("[DEBUG] RabbitMQ: Revoking the unexpected permissions of %s@%s", p.User, vhost)
+		resp, err := rmqc.ClearPermissionsIn(vhost, p.User)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode >= 400 && resp.StatusCode != 404 {
+			return fmt.Errorf("error revoking RabbitMQ permission of '%s' in vhost '%s': %s", p.User, vhost, resp.Status)
+		}
+	}
+
+	return nil
+}
+
+// get the permissions of all the users in a vhost
+func listPermissionsIn(rmqc *rabbithole.Client, vhost string) ([]rabbithole.PermissionInfo, error) {
+	allPerms, err := rmqc.ListPermissions()
+	if err != nil {
+		return nil, err
+	}
+
+	var perms []rabbithole.PermissionInfo
+	for _, p := range allPerms {
+		if p.Vhost == vhost {
+			perms = append(perms, p)
+		}
+	}
+
+	return perms, nil
+}
+
+func ignoredUsers(d *schema.ResourceData) map[string]bool {
+	ignored := make(map[string]bool)
+
+	for _, v := range d.Get("ignore_users").(*schema.Set).List() {
+		ignored[v.(string)] = true
+	}
+
+	return ignored
+}
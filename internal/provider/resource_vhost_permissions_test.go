@@ -0,0 +1,61 @@
+package provider_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/acceptance"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/acceptance/check"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVhostPermissions_Basic(t *testing.T) {
+	data := acceptance.BuildTestData("rabbitmq_vhost_permissions", "test")
+	r := acceptance.VhostPermissionsResource{
+		Vhost:          data.RandomString(),
+		Users:          []string{data.RandomString(), data.RandomString()},
+		UnexpectedUser: data.RandomString(),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAcc.PreCheck(t) },
+		Providers:    acceptance.TestAcc.Providers,
+		CheckDestroy: r.CheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: r.Create(data),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Exists(),
+					check.That(data.ResourceName).Key("id").MatchesOtherKey("vhost"),
+					check.That(data.ResourceName).Key("permissions").Count(len(r.Users)),
+					check.That(data.ResourceName).ExistsInRabbitMQ(r),
+				),
+			},
+			{
+				// The unexpected user is revoked, the ignored one is kept
+				PreConfig: func() { r.SetUnexpectedPermissions(t) },
+				Config:    r.Create(data),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Key("permissions").Count(len(r.Users)),
+					check.That(data.ResourceName).ExistsInRabbitMQ(r),
+				),
+			},
+			{
+				Config: r.Update(data),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Key("permissions").Count(len(r.Users)),
+					check.That(data.ResourceName).ExistsInRabbitMQ(r),
+				),
+			},
+			{
+				ResourceName: data.ResourceName,
+				// The ignored users are given with the vhost
+				ImportStateId:     fmt.Sprintf("%s@%s", os.Getenv("RABBITMQ_USERNAME"), r.Vhost),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -117,8 +117,8 @@ func TestAccVhost_Optional(t *testing.T) {
 					check.That(data.ResourceName).Key("name").HasValue(r.Name),
 					check.That(data.ResourceName).Key("description").RmqFeature(r.HasDescriptionUpdateFeature()).IsEmpty(),
 					check.That(data.ResourceName).Key("default_queue_type").RmqFeature(r.HasDefaultQueueTypeUpdateFeature()).HasValue("classic"),
-					check.That(data.ResourceName).Key("max_connections").IsEmpty(),
-					check.That(data.ResourceName).Key("max_queues").IsEmpty(),
+					check.That(data.ResourceName).Key("max_connections").DoesNotExist(),
+					check.That(data.ResourceName).Key("max_queues").DoesNotExist(),
 					check.That(data.ResourceName).Key("tracing").HasValue("false"),
 					check.That(data.ResourceName).ExistsInRabbitMQ(r),
 				),
@@ -203,7 +203,7 @@ func TestAccVhost_AlredayExist(t *testing.T) {
 	})
 }
 
-func TestAccVhost_ErrorConvertingMaxConnections(t *testing.T) {
+func TestAccVhost_InvalidMaxConnections(t *testing.T) {
 	data := acceptance.BuildTestData("rabbitmq_vhost", "test")
 	r := acceptance.VhostResource{Name: data.RandomString(), MaxConnections: data.RandomString()}
 
@@ -213,27 +213,27 @@ func TestAccVhost_ErrorConvertingMaxConnections(t *testing.T) {
 		CheckDestroy: r.CheckDestroy(),
 		Steps: []resource.TestStep{
 			{
-				Config:      r.ErrorConvertingCreate(data),
-				ExpectError: regexp.MustCompile("error converting 'max_connections' to int"),
+				Config:      r.LimitsCreate(data),
+				ExpectError: regexp.MustCompile("a number is required"),
 			},
 			{
-				Config: r.ErrorConvertingUpdate(data, data.RandomIntegerString(), r.MaxQueues),
+				Config: r.LimitsUpdate(data, data.RandomIntegerString(), r.MaxQueues),
 				Check: resource.ComposeTestCheckFunc(
 					check.That(data.ResourceName).Exists(),
 					check.That(data.ResourceName).Key("max_connections").HasValue(r.MaxConnections),
-					check.That(data.ResourceName).Key("max_queues").IsEmpty(),
+					check.That(data.ResourceName).Key("max_queues").DoesNotExist(),
 					check.That(data.ResourceName).ExistsInRabbitMQ(r),
 				),
 			},
 			{
-				Config:      r.ErrorConvertingUpdate(data, data.RandomString(), r.MaxQueues),
-				ExpectError: regexp.MustCompile("error converting 'max_connections' to int"),
+				Config:      r.LimitsUpdate(data, "-5", r.MaxQueues),
+				ExpectError: regexp.MustCompile(`expected max_connections to be at least \(-1\)`),
 			},
 		},
 	})
 }
 
-func TestAccVhost_ErrorConvertingMaxQueues(t *testing.T) {
+func TestAccVhost_InvalidMaxQueues(t *testing.T) {
 	data := acceptance.BuildTestData("rabbitmq_vhost", "test")
 	r := acceptance.VhostResource{Name: data.RandomString(), MaxQueues: data.RandomString()}
 
@@ -243,21 +243,21 @@ func TestAccVhost_ErrorConvertingMaxQueues(t *testing.T) {
 		CheckDestroy: r.CheckDestroy(),
 		Steps: []resource.TestStep{
 			{
-				Config:      r.ErrorConvertingCreate(data),
-				ExpectError: regexp.MustCompile("error converting 'max_queues' to int"),
+				Config:      r.LimitsCreate(data),
+				ExpectError: regexp.MustCompile("a number is required"),
 			},
 			{
-				Config: r.ErrorConvertingUpdate(data, r.MaxConnections, data.RandomIntegerString()),
+				Config: r.LimitsUpdate(data, r.MaxConnections, data.RandomIntegerString()),
 				Check: resource.ComposeTestCheckFunc(
 					check.That(data.ResourceName).Exists(),
 					check.That(data.ResourceName).Key("max_queues").HasValue(r.MaxQueues),
-					check.That(data.ResourceName).Key("max_connections").IsEmpty(),
+					check.That(data.ResourceName).Key("max_connections").DoesNotExist(),
 					check.That(data.ResourceName).ExistsInRabbitMQ(r),
 				),
 			},
 			{
-				Config:      r.ErrorConvertingUpdate(data, r.MaxConnections, data.RandomString()),
-				ExpectError: regexp.MustCompile("error converting 'max_queues' to int"),
+				Config:      r.LimitsUpdate(data, r.MaxConnections, "-5"),
+				ExpectError: regexp.MustCompile(`expected max_queues to be at least \(-1\)`),
 			},
 		},
 	})
@@ -279,3 +279,136 @@ func TestAccVhost_ErrorDefaultQueueTypeAttribute(t *testing.T) {
 		},
 	})
 }
+
+func TestAccVhost_RemoveOneLimit(t *testing.T) {
+	data := acceptance.BuildTestData("rabbitmq_vhost", "test")
+	r := acceptance.VhostResource{Name: data.RandomString(), MaxConnections: data.RandomIntegerString(), MaxQueues: data.RandomIntegerString()}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAcc.PreCheck(t) },
+		Providers:    acceptance.TestAcc.Providers,
+		CheckDestroy: r.CheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: r.LimitsCreate(data),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Exists(),
+					check.That(data.ResourceName).Key("max_connections").HasValue(r.MaxConnections),
+					check.That(data.ResourceName).Key("max_queues").HasValue(r.MaxQueues),
+					check.That(data.ResourceName).ExistsInRabbitMQ(r),
+				),
+			},
+			{
+				Config: r.LimitsUpdate(data, r.MaxConnections, ""),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Exists(),
+					check.That(data.ResourceName).Key("max_connections").HasValue(r.MaxConnections),
+					check.That(data.ResourceName).Key("max_queues").DoesNotExist(),
+					check.That(data.ResourceName).ExistsInRabbitMQ(r),
+				),
+			},
+		},
+	})
+}
+
+func TestAccVhost_Tags(t *testing.T) {
+	data := acceptance.BuildTestData("rabbitmq_vhost", "test")
+	r := acceptance.VhostResource{Name: data.RandomString(), Tags: []string{"foo", "bar"}}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAcc.PreCheck(t) },
+		Providers:    acceptance.TestAcc.Providers,
+		CheckDestroy: r.CheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: r.TagsCreate(data),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Exists(),
+					check.That(data.ResourceName).Key("tags").Count(len(r.Tags)),
+					check.That(data.ResourceName).Key("tags.0").HasValue(r.Tags[0]),
+					check.That(data.ResourceName).Key("tags.1").HasValue(r.Tags[1]),
+					check.That(data.ResourceName).ExistsInRabbitMQ(r),
+				),
+			},
+			{
+				Config: r.TagsUpdate(data),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Exists(),
+					check.That(data.ResourceName).Key("tags").Count(len(r.Tags)),
+					check.That(data.ResourceName).Key("tags.0").HasValue(r.Tags[0]),
+					check.That(data.ResourceName).ExistsInRabbitMQ(r),
+				),
+			},
+		},
+	})
+}
+
+func TestAccVhost_ProtectedFromDeletion(t *testing.T) {
+	data := acceptance.BuildTestData("rabbitmq_vhost", "test")
+	r := acceptance.VhostResource{Name: data.RandomString()}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAcc.PreCheck(t)
+			if !r.HasDeletionProtectionFeature() {
+				t.Skip("The deletion protection of a vhost requires RabbitMQ 4.1 or later")
+			}
+		},
+		Providers:    acceptance.TestAcc.Providers,
+		CheckDestroy: r.CheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: r.ProtectedFromDeletion(data, true),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Exists(),
+					check.That(data.ResourceName).Key("protected_from_deletion").IsBool(true),
+				),
+			},
+			{
+				Config:      r.ProtectedFromDeletion(data, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("the vhost is protected from deletion"),
+			},
+			{
+				Config: r.ProtectedFromDeletion(data, false),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Exists(),
+					check.That(data.ResourceName).Key("protected_from_deletion").IsBool(false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccVhost_PreventDestroyIfNotEmpty(t *testing.T) {
+	data := acceptance.BuildTestData("rabbitmq_vhost", "test")
+	r := acceptance.VhostResource{Name: data.RandomString()}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAcc.PreCheck(t) },
+		Providers:    acceptance.TestAcc.Providers,
+		CheckDestroy: r.CheckDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: r.PreventDestroyIfNotEmpty(data, true),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Exists(),
+					check.That(data.ResourceName).Key("prevent_destroy_if_not_empty").IsBool(true),
+				),
+			},
+			{
+				PreConfig:   func() { r.SetQueueWithMessage(t, "not_empty") },
+				Config:      r.PreventDestroyIfNotEmpty(data, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`queues with messages: 'not_empty' \(1 messages\)`),
+			},
+			{
+				Config: r.PreventDestroyIfNotEmpty(data, false),
+				Check: resource.ComposeTestCheckFunc(
+					check.That(data.ResourceName).Exists(),
+					check.That(data.ResourceName).Key("prevent_destroy_if_not_empty").IsBool(false),
+				),
+			},
+		},
+	})
+}
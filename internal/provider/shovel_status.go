@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+)
+
+const (
+	shovelStarting   = "starting"
+	shovelRunning    = "running"
+	shovelTerminated = "terminated"
+	// the shovel deleted itself after transferring the messages of its source
+	shovelCompleted = "completed"
+)
+
+// shovelStatus is the status of a shovel reported by the shovels endpoint, rabbit-hole doesn't decode its node and reason
+type shovelStatus struct {
+	Name      string      `json:"name"`
+	Vhost     string      `json:"vhost"`
+	Type      string      `json:"type"`
+	State     string      `json:"state"`
+	Node      string      `json:"node"`
+	Timestamp string      `json:"timestamp"`
+	Reason    interface{} `json:"reason"`
+	// the number of messages transferred since the shovel started, not reported by the old versions of RabbitMQ
+	Forwarded *int `json:"forwarded"`
+}
+
+// the reason of a terminated shovel, an empty string for the other states
+func (s *shovelStatus) lastError() string {
+	if s == nil || s.State != shovelTerminated || s.Reason == nil {
+		return ""
+	}
+	if reason, ok := s.Reason.(string); ok {
+		return strings.TrimSpace(reason)
+	}
+	return fmt.Sprint(s.Reason)
+}
+
+// get the status of a shovel, nil when the shovel doesn't report a status yet
+func getShovelStatus(m *Meta, vhost string, name string) (*shovelStatus, error) {
+	var statuses []shovelStatus
+	if err := apiRequest(m, "GET", "shovels/"+url.PathEscape(vhost), nil, &statuses); err != nil {
+		return nil, err
+	}
+
+	for _, s := range statuses {
+		if s.Name == name && s.Vhost == vhost {
+			return &s, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// get the state of a shovel, an error is returned when the shovel is terminated
+func shovelState(status *shovelStatus) (string, error) {
+	if status == nil {
+		return shovelStarting, nil
+	}
+
+	switch status.State {
+	case shovelRunning:
+		return shovelRunning, nil
+	case shovelTerminated:
+		return "", fmt.Errorf("the shovel '%s@%s' terminated on node '%s': %s", status.Name, status.Vhost, status.Node, status.lastError())
+	default:
+		return shovelStarting, nil
+	}
+}
+
+// restart a shovel, its connections are closed and reopened
+func restartShovel(m *Meta, vhost string, name string) error {
+	log.Printf("[DEBUG] RabbitMQ: Attempting to restart shovel %s@%s", name, vhost)
+
+	if err := apiRequest(m, "DELETE", "shovels/vhost/"+url.PathEscape(vhost)+"/"+url.PathEscape(name)+"/restart", nil, nil); err != nil {
+		return fmt.Errorf("error restarting shovel '%s@%s': %v", name, vhost, err)
+	}
+
+	return nil
+}
+
+// wait until a shovel is running, fail with the reason of a terminated shovel
+func waitForShovel(ctx context.Context, m *Meta, vhost string, name string, timeout time.Duration) error {
+	log.Printf("[DEBUG] RabbitMQ: Waiting for shovel %s@%s to be running", name, vhost)
+
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{shovelStarting},
+		Target:  []string{shovelRunning, shovelCompleted},
+		Refresh: func() (interface{}, string, error) {
+			status, err := getShovelStatus(m, vhost, name)
+			if err != nil {
+				return nil, "", err
+			}
+
+			// a shovel with 'source_delete_after' may delete itself before it's reported as running
+			if status == nil {
+				if _, err := m.Client.GetShovel(vhost, name); err != nil {
+					var errorResponse rabbithole.ErrorResponse
+					if errors.As(err, &errorResponse) && errorResponse.StatusCode == 404 {
+						return name, shovelCompleted, nil
+					}
+					return nil, "", err
+				}
+			}
+
+			state, err := shovelState(status)
+			return status, state, err
+		},
+		Timeout:                   timeout,
+		MinTimeout:                time.Second,
+		ContinuousTargetOccurence: 2,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for shovel '%s@%s': %v", name, vhost, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShovelState(t *testing.T) {
+	var tests = []struct {
+		status *shovelStatus
+		state  string
+		err    string
+	}{
+		{nil, shovelStarting, ""},
+		{&shovelStatus{Name: "test", Vhost: "/", State: "starting", Node: "rabbit@a"}, shovelStarting, ""},
+		{&shovelStatus{Name: "test", Vhost: "/", State: "running", Node: "rabbit@a"}, shovelRunning, ""},
+		{&shovelStatus{Name: "test", Vhost: "/", State: "terminated", Node: "rabbit@b", Reason: "{shutdown,{server_initiated_close,404,<<\"NOT_FOUND - no queue 'missing'\">>}}\n"}, "", "terminated on node 'rabbit@b': {shutdown,{server_initiated_close,404,<<\"NOT_FOUND - no queue 'missing'\">>}}"},
+	}
+
+	for _, test := range tests {
+		state, err := shovelState(test.status)
+		if test.err != "" {
+			if err == nil || !strings.HasSuffix(err.Error(), test.err) {
+				t.Errorf("shovelState should fail for %#v: %v", test.status, err)
+			}
+			continue
+		}
+		if err != nil || state != test.state {
+			t.Errorf("shovelState failed for %#v: expected %s, got %s (%v)", test.status, test.state, state, err)
+		}
+	}
+}
+
+func TestShovelStatusLastError(t *testing.T) {
+	var tests = []struct {
+		status   *shovelStatus
+		expected string
+	}{
+		{nil, ""},
+		{&shovelStatus{State: "running", Reason: "ignored"}, ""},
+		{&shovelStatus{State: "terminated", Reason: " needs_restart "}, "needs_restart"},
+		{&shovelStatus{State: "terminated", Reason: map[string]interface{}{"error": "timeout"}}, "map[error:timeout]"},
+	}
+
+	for _, test := range tests {
+		if lastError := test.status.lastError(); lastError != test.expected {
+			t.Errorf("lastError failed for %#v: expected %s, got %s", test.status, test.expected, lastError)
+		}
+	}
+}
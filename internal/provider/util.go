@@ -1,11 +1,14 @@
 package provider
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 )
@@ -60,3 +63,140 @@ func failApiResponse(err error, resp *http.Response, action string, name string)
 		return fmt.Errorf("error %s RabbitMQ %s: %s", action, name, resp.Status)
 	}
 }
+
+// limitAttribute links a limit attribute of a resource schema to its RabbitMQ limit name
+type limitAttribute struct {
+	attribute string
+	key       string
+}
+
+// get the integer value of an attribute from the raw config or state, 'ok' is false when the value is null
+func getRawInt(raw cty.Value, attribute string) (value int, ok bool) {
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().IsObjectType() || !raw.Type().HasAttribute(attribute) {
+		return 0, false
+	}
+
+	v := raw.GetAttr(attribute)
+	if v.IsNull() || !v.IsKnown() {
+		return 0, false
+	}
+
+	i, _ := v.AsBigFloat().Int64()
+	return int(i), true
+}
+
+// a limit is managed when it is set into the configuration or into the state of the resource
+func isLimitManaged(d *schema.ResourceData, attribute string) bool {
+	if _, ok := getRawInt(d.GetRawConfig(), attribute); ok {
+		return true
+	}
+	_, ok := getRawInt(d.GetRawState(), attribute)
+	return ok
+}
+
+// set the managed limits from the current limits on the server and return how many are still present
+func setManagedLimits(d *schema.ResourceData, attributes []limitAttribute, current map[string]int) int {
+	found := 0
+	for _, l := range attributes {
+		if !isLimitManaged(d, l.attribute) {
+			continue
+		}
+		if val, ok := current[l.key]; ok {
+			d.Set(l.attribute, val)
+			found++
+		} else {
+			d.Set(l.attribute, nil)
+		}
+	}
+	return found
+}
+
+// compute the limits to put and the limits to remove by comparing the configuration with the state of the resource.
+// 'conflict' is set when a limit not managed yet by the resource is already set on the server.
+func planLimits(d *schema.ResourceData, attributes []limitAttribute, current map[string]int) (put map[string]int, removed []string, conflict *limitAttribute) {
+	put = make(map[string]int)
+
+	for i, l := range attributes {
+		v, isSet := getRawInt(d.GetRawConfig(), l.attribute)
+		_, wasSet := getRawInt(d.GetRawState(), l.attribute)
+
+		if isSet {
+			if _, exists := current[l.key]; exists && !wasSet {
+				return nil, nil, &attributes[i]
+			}
+			put[l.key] = v
+		} else if wasSet {
+			removed = append(removed, l.key)
+		}
+	}
+
+	return put, removed, nil
+}
+
+// the limits set into the configuration but not into the state, i.e. the limits the resource starts to manage
+func newLimits(config cty.Value, state cty.Value, attributes []limitAttribute) []limitAttribute {
+	var limits []limitAttribute
+	for _, l := range attributes {
+		_, isSet := getRawInt(config, l.attribute)
+		_, wasSet := getRawInt(state, l.attribute)
+		if isSet && !wasSet {
+			limits = append(limits, l)
+		}
+	}
+	return limits
+}
+
+// fail at plan when a limit the resource starts to manage is already set on the server, e.g. by the resource named by
+// 'owner'. The check is skipped when the current limits can't be read, e.g. when the user or the vhost doesn't exist
+// yet, so a conflict with a limit set by another resource of the same apply is only detected at apply.
+func customizeLimitsDiff(nameAttribute string, attributes []limitAttribute, owner string, current func(*rabbithole.Client, string) (map[string]int, error)) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+		m, ok := meta.(*Meta)
+		if !ok || !diff.NewValueKnown(nameAttribute) {
+			return nil
+		}
+
+		limits := newLimits(diff.GetRawConfig(), diff.GetRawState(), attributes)
+		if len(limits) == 0 {
+			return nil
+		}
+
+		name := diff.Get(nameAttribute).(string)
+		values, err := current(m.Client, name)
+		if err != nil {
+			return nil
+		}
+
+		for _, l := range limits {
+			if _, exists := values[l.key]; exists {
+				return fmt.Errorf("limit '%s' of '%s' is already set, it may be managed by %s: remove the `%s` attribute from one of the resources", l.key, name, owner, l.attribute)
+			}
+		}
+		return nil
+	}
+}
+
+// migrate the limits stored as strings by the version 0 of a schema to integers, an empty string means no limit
+func upgradeLimitsStateV0(rawState map[string]interface{}, attributes []limitAttribute) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	for _, l := range attributes {
+		v, ok := rawState[l.attribute].(string)
+		if !ok {
+			continue
+		}
+		if v == "" {
+			delete(rawState, l.attribute)
+			continue
+		}
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("error converting '%s' to int: %#v", l.attribute, v)
+		}
+		rawState[l.attribute] = i
+	}
+
+	return rawState, nil
+}
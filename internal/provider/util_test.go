@@ -1,6 +1,11 @@
 package provider
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
 
 func TestParseId(t *testing.T) {
 	var badInputs = []string{
@@ -34,3 +39,94 @@ func TestParseId(t *testing.T) {
 		}
 	}
 }
+
+func TestParseParameterId(t *testing.T) {
+	var badInputs = []string{
+		"",
+		"foo@test",
+		"component/foo@bar@test",
+	}
+
+	for _, input := range badInputs {
+		_, _, _, err := parseParameterId(input)
+		if err == nil {
+			t.Errorf("parseParameterId failed for: %s.", input)
+		}
+	}
+
+	var goodInputs = []struct {
+		input     string
+		component string
+		name      string
+		vhost     string
+	}{
+		{"federation-upstream-set/foo@test", "federation-upstream-set", "foo", "test"},
+		{"shovel/foo/bar@/", "shovel", "foo/bar", "/"},
+	}
+
+	for _, test := range goodInputs {
+		component, name, vhost, err := parseParameterId(test.input)
+		if err != nil || component != test.component || name != test.name || vhost != test.vhost {
+			t.Errorf("parseParameterId failed for: %s.", test.input)
+		}
+	}
+}
+
+func TestUpgradeLimitsStateV0(t *testing.T) {
+	var tests = []struct {
+		input    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			map[string]interface{}{"name": "foo", "max_connections": "10", "max_channels": "-1"},
+			map[string]interface{}{"name": "foo", "max_connections": 10, "max_channels": -1},
+		},
+		{
+			map[string]interface{}{"name": "foo", "max_connections": "", "max_channels": "5"},
+			map[string]interface{}{"name": "foo", "max_channels": 5},
+		},
+		{
+			map[string]interface{}{"name": "foo"},
+			map[string]interface{}{"name": "foo"},
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := upgradeLimitsStateV0(test.input, userLimitAttributes)
+		if err != nil {
+			t.Errorf("upgradeLimitsStateV0 failed: %v", err)
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("upgradeLimitsStateV0 failed. Actual: %#v Expected: %#v", actual, test.expected)
+		}
+	}
+
+	if _, err := upgradeLimitsStateV0(map[string]interface{}{"max_queues": "ten"}, vhostLimitAttributes); err == nil {
+		t.Errorf("upgradeLimitsStateV0 should fail for a non numeric limit.")
+	}
+}
+
+func TestNewLimits(t *testing.T) {
+	limits := func(connections cty.Value, channels cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{"max_connections": connections, "max_channels": channels})
+	}
+	null := cty.NullVal(cty.Number)
+
+	var tests = []struct {
+		config   cty.Value
+		state    cty.Value
+		expected []limitAttribute
+	}{
+		{limits(cty.NumberIntVal(10), null), cty.NullVal(cty.DynamicPseudoType), []limitAttribute{userLimitAttributes[0]}},
+		{limits(cty.NumberIntVal(10), cty.NumberIntVal(5)), limits(cty.NumberIntVal(20), null), []limitAttribute{userLimitAttributes[1]}},
+		{limits(cty.NumberIntVal(10), null), limits(cty.NumberIntVal(20), null), nil},
+		{limits(null, null), limits(cty.NumberIntVal(20), null), nil},
+	}
+
+	for _, test := range tests {
+		actual := newLimits(test.config, test.state, userLimitAttributes)
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("newLimits failed. Actual: %#v Expected: %#v", actual, test.expected)
+		}
+	}
+}
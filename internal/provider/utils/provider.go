@@ -1,9 +1,12 @@
 package utils
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -11,6 +14,10 @@ import (
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 )
 
+// The plain decimal numbers of the comma-separated lists, e.g. '12', '-1.5'. The other forms accepted by
+// strconv.ParseFloat ('inf', 'nan', '0x1p4', ...) are kept as strings.
+var listNumberRegexp = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
 func BuildResourceId(name, vhost string) string {
 	return fmt.Sprintf("%s@%s", name, vhost)
 }
@@ -47,7 +54,7 @@ func CheckDeletedResource(d *schema.ResourceData, err error) error {
 }
 
 func GetArgumentValue(arg map[string]interface{}) (interface{}, error) {
-	switch arg["type"].(string) {
+	switch strings.ToLower(arg["type"].(string)) {
 	case "numeric":
 		if value, err := strconv.ParseFloat(arg["value"].(string), 64); err != nil {
 			return nil, fmt.Errorf("failed to parse number %q", arg["value"].(string))
@@ -61,12 +68,56 @@ func GetArgumentValue(arg map[string]interface{}) (interface{}, error) {
 			return value, nil
 		}
 	case "list":
-		return arg["value"].(string), nil
+		if value, err := parseArgumentList(arg["value"].(string)); err != nil {
+			return nil, err
+		} else {
+			return value, nil
+		}
+	case "table":
+		var value map[string]interface{}
+		if err := json.Unmarshal([]byte(arg["value"].(string)), &value); err != nil {
+			return nil, fmt.Errorf("failed to parse table %q", arg["value"].(string))
+		}
+		return value, nil
 	default:
 		return arg["value"].(string), nil
 	}
 }
 
+// parse a list argument, a JSON array or comma-separated values whose elements are typed as plain decimal numbers,
+// the booleans 'true' and 'false' or strings
+func parseArgumentList(value string) ([]interface{}, error) {
+	value = strings.TrimSpace(value)
+
+	if strings.HasPrefix(value, "[") {
+		var list []interface{}
+		if err := json.Unmarshal([]byte(value), &list); err != nil {
+			return nil, fmt.Errorf("failed to parse list %q", value)
+		}
+		return list, nil
+	}
+
+	list := []interface{}{}
+	if value == "" {
+		return list, nil
+	}
+	for _, e := range strings.Split(value, ",") {
+		e = strings.TrimSpace(e)
+		if listNumberRegexp.MatchString(e) {
+			number, err := strconv.ParseFloat(e, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse number %q of list %q", e, value)
+			}
+			list = append(list, number)
+		} else if e == "true" || e == "false" {
+			list = append(list, e == "true")
+		} else {
+			list = append(list, e)
+		}
+	}
+	return list, nil
+}
+
 func GetArgumentType(value interface{}) string {
 	switch value.(type) {
 	case int:
@@ -75,7 +126,41 @@ func GetArgumentType(value interface{}) string {
 		return "numeric"
 	case bool:
 		return "boolean"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "table"
 	default:
 		return "string"
 	}
 }
+
+// format an argument value returned by the API, lists and tables are formatted as JSON
+func FormatArgumentValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}, map[string]interface{}:
+		if data, err := json.Marshal(v); err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// compare two argument values by their JSON form, so an int and a float64 of the same number are equal
+func EqualArgumentValues(a, b interface{}) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	var valueA, valueB interface{}
+	if json.Unmarshal(dataA, &valueA) != nil || json.Unmarshal(dataB, &valueB) != nil {
+		return false
+	}
+	return reflect.DeepEqual(valueA, valueB)
+}
@@ -113,14 +113,23 @@ func TestProvider_GetArgumentValue(t *testing.T) {
 	}
 
 	for id, testCase := range map[string]testCaseStruct{
-		"string":        {arg: map[string]interface{}{"type": "string", "value": "myString"}, expected: testExpectedStruct{value: "myString", err: false}},
-		"boolean":       {arg: map[string]interface{}{"type": "boolean", "value": "true"}, expected: testExpectedStruct{value: true, err: false}},
-		"numeric int":   {arg: map[string]interface{}{"type": "numeric", "value": "12345"}, expected: testExpectedStruct{value: float64(12345), err: false}},
-		"numeric float": {arg: map[string]interface{}{"type": "numeric", "value": "123.45"}, expected: testExpectedStruct{value: 123.45, err: false}},
-		"list":          {arg: map[string]interface{}{"type": "list", "value": "myString, true, 12345"}, expected: testExpectedStruct{value: "myString, true, 12345", err: false}},
-		"other":         {arg: map[string]interface{}{"type": "OtherType", "value": "12345"}, expected: testExpectedStruct{value: "12345", err: false}},
-		"error boolean": {arg: map[string]interface{}{"type": "boolean", "value": "NotBooleanValue"}, expected: testExpectedStruct{value: nil, err: true}},
-		"error numeric": {arg: map[string]interface{}{"type": "numeric", "value": "NotNumericValue"}, expected: testExpectedStruct{value: nil, err: true}},
+		"string":                {arg: map[string]interface{}{"type": "string", "value": "myString"}, expected: testExpectedStruct{value: "myString", err: false}},
+		"boolean":               {arg: map[string]interface{}{"type": "boolean", "value": "true"}, expected: testExpectedStruct{value: true, err: false}},
+		"numeric int":           {arg: map[string]interface{}{"type": "numeric", "value": "12345"}, expected: testExpectedStruct{value: float64(12345), err: false}},
+		"numeric float":         {arg: map[string]interface{}{"type": "numeric", "value": "123.45"}, expected: testExpectedStruct{value: 123.45, err: false}},
+		"list":                  {arg: map[string]interface{}{"type": "list", "value": "myString, true, 12345"}, expected: testExpectedStruct{value: []interface{}{"myString", true, float64(12345)}, err: false}},
+		"list json":             {arg: map[string]interface{}{"type": "list", "value": `["12345", true, 1.5, ["a"]]`}, expected: testExpectedStruct{value: []interface{}{"12345", true, 1.5, []interface{}{"a"}}, err: false}},
+		"list empty":            {arg: map[string]interface{}{"type": "list", "value": ""}, expected: testExpectedStruct{value: []interface{}{}, err: false}},
+		"list special numbers":  {arg: map[string]interface{}{"type": "list", "value": "inf, nan, infinity, 0x1p4, 1e3, -1.5"}, expected: testExpectedStruct{value: []interface{}{"inf", "nan", "infinity", "0x1p4", "1e3", -1.5}, err: false}},
+		"list special booleans": {arg: map[string]interface{}{"type": "list", "value": "t, T, f, F, TRUE, false"}, expected: testExpectedStruct{value: []interface{}{"t", "T", "f", "F", "TRUE", false}, err: false}},
+		"list mixed":            {arg: map[string]interface{}{"type": "list", "value": "a, t, nan"}, expected: testExpectedStruct{value: []interface{}{"a", "t", "nan"}, err: false}},
+		"type case":             {arg: map[string]interface{}{"type": "Numeric", "value": "12345"}, expected: testExpectedStruct{value: float64(12345), err: false}},
+		"error list":            {arg: map[string]interface{}{"type": "list", "value": "[myString"}, expected: testExpectedStruct{value: nil, err: true}},
+		"table":                 {arg: map[string]interface{}{"type": "table", "value": `{"myKey": [1, "a"]}`}, expected: testExpectedStruct{value: map[string]interface{}{"myKey": []interface{}{float64(1), "a"}}, err: false}},
+		"error table":           {arg: map[string]interface{}{"type": "table", "value": "[1, 2]"}, expected: testExpectedStruct{value: nil, err: true}},
+		"other":                 {arg: map[string]interface{}{"type": "OtherType", "value": "12345"}, expected: testExpectedStruct{value: "12345", err: false}},
+		"error boolean":         {arg: map[string]interface{}{"type": "boolean", "value": "NotBooleanValue"}, expected: testExpectedStruct{value: nil, err: true}},
+		"error numeric":         {arg: map[string]interface{}{"type": "numeric", "value": "NotNumericValue"}, expected: testExpectedStruct{value: nil, err: true}},
 	} {
 		t.Run(id, func(t *testing.T) {
 
@@ -145,6 +154,8 @@ func TestProvider_GetArgumentType(t *testing.T) {
 		"boolean":       {value: true, expected: "boolean"},
 		"numeric int":   {value: 12345, expected: "numeric"},
 		"numeric float": {value: 123.45, expected: "numeric"},
+		"list":          {value: []interface{}{"a", 1}, expected: "list"},
+		"table":         {value: map[string]interface{}{"a": 1}, expected: "table"},
 		"other":         {value: errors.New("test"), expected: "string"},
 	} {
 		t.Run(id, func(t *testing.T) {
@@ -155,3 +166,57 @@ func TestProvider_GetArgumentType(t *testing.T) {
 		})
 	}
 }
+
+func TestProvider_FormatArgumentValue(t *testing.T) {
+	assert := assert.New(t)
+
+	type testCaseStruct struct {
+		value    interface{}
+		expected string
+	}
+
+	for id, testCase := range map[string]testCaseStruct{
+		"string":        {value: "myString", expected: "myString"},
+		"boolean":       {value: true, expected: "true"},
+		"numeric int":   {value: 12345, expected: "12345"},
+		"numeric float": {value: float64(12345), expected: "12345"},
+		"numeric big":   {value: float64(100000000), expected: "100000000"},
+		"numeric dec":   {value: 123.45, expected: "123.45"},
+		"list":          {value: []interface{}{"a", float64(1), true}, expected: `["a",1,true]`},
+		"table":         {value: map[string]interface{}{"b": []interface{}{"c"}, "a": float64(1)}, expected: `{"a":1,"b":["c"]}`},
+	} {
+		t.Run(id, func(t *testing.T) {
+
+			data := utils.FormatArgumentValue(testCase.value)
+
+			assert.Equal(testCase.expected, data)
+		})
+	}
+}
+
+func TestProvider_EqualArgumentValues(t *testing.T) {
+	assert := assert.New(t)
+
+	type testCaseStruct struct {
+		a        interface{}
+		b        interface{}
+		expected bool
+	}
+
+	for id, testCase := range map[string]testCaseStruct{
+		"string":            {a: "myString", b: "myString", expected: true},
+		"int and float":     {a: 12345, b: float64(12345), expected: true},
+		"different numbers": {a: 12345, b: 123.45, expected: false},
+		"number and string": {a: 12345, b: "12345", expected: false},
+		"list":              {a: []interface{}{"a", 1}, b: []interface{}{"a", float64(1)}, expected: true},
+		"list order":        {a: []interface{}{"a", "b"}, b: []interface{}{"b", "a"}, expected: false},
+		"table":             {a: map[string]interface{}{"a": 1}, b: map[string]interface{}{"a": float64(1)}, expected: true},
+	} {
+		t.Run(id, func(t *testing.T) {
+
+			data := utils.EqualArgumentValues(testCase.a, testCase.b)
+
+			assert.Equal(testCase.expected, data)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The errors of the Go 'regexp' package which are also raised for some valid PCRE expressions, e.g. for the escape
+// sequences or the group constructs which are unknown by Go
+var unsupportedSyntaxErrors = []syntax.ErrorCode{
+	syntax.ErrInvalidEscape,
+	syntax.ErrInvalidPerlOp,
+	syntax.ErrInvalidNamedCapture,
+	syntax.ErrLarge,
+	syntax.ErrNestingDepth,
+}
+
+var repeatRegexp = regexp.MustCompile(`^\{([0-9]+)(,([0-9]*))?\}$`)
+
+// RabbitMQ evaluates the regular expressions with the Erlang 're' module, which uses the PCRE syntax.
+// The PCRE constructs which are not supported by the Go 'regexp' package (lookarounds, backreferences,
+// atomic groups, possessive quantifiers, ...) are replaced by constructs with the same syntax rules,
+// so the result can be compiled to check the syntax of the expression.
+func toGoRegexSyntax(expr string) string {
+	var sb strings.Builder
+	n := len(expr)
+	afterQuantifier := false
+
+	for i := 0; i < n; i++ {
+		c := expr[i]
+		quantifier := false
+
+		switch {
+		case c == '\\' && i+1 < n:
+			next := expr[i+1]
+			i++
+			switch {
+			case next >= '1' && next <= '9':
+				// backreference: \1
+				for i+1 < n && expr[i+1] >= '0' && expr[i+1] <= '9' {
+					i++
+				}
+				sb.WriteByte('x')
+			case next == 'g' || next == 'k':
+				// backreference: \g1, \g{-1}, \k<name>, \k{name}, \k'name'
+				if i+1 < n && strings.IndexByte("<{'", expr[i+1]) >= 0 {
+					if end := strings.IndexAny(expr[i+2:], ">}'"); end >= 0 {
+						i += end + 2
+					}
+				} else {
+					for i+1 < n && (expr[i+1] == '-' || (expr[i+1] >= '0' && expr[i+1] <= '9')) {
+						i++
+					}
+				}
+				sb.WriteByte('x')
+			case next == 'c' && i+1 < n:
+				// control character: \cX
+				i++
+				sb.WriteByte('x')
+			case next == 'Z':
+				sb.WriteString(`\z`)
+			case strings.IndexByte("hHvVRXKGeN", next) >= 0:
+				sb.WriteByte('x')
+			default:
+				sb.WriteByte(c)
+				sb.WriteByte(next)
+			}
+
+		case c == '[':
+			// the character classes are copied, a ']' at the beginning is a literal
+			j := i + 1
+			if j < n && expr[j] == '^' {
+				j++
+			}
+			if j < n && expr[j] == ']' {
+				j++
+			}
+			for ; j < n && expr[j] != ']'; j++ {
+				if expr[j] == '\\' {
+					j++
+				} else if strings.HasPrefix(expr[j:], "[:") {
+					if end := strings.Index(expr[j:], ":]"); end >= 0 {
+						j += end + 1
+					}
+				}
+			}
+			if j >= n {
+				// let the parser report the missing bracket
+				j = n - 1
+			}
+			sb.WriteString(expr[i : j+1])
+			i = j
+
+		case strings.HasPrefix(expr[i:], "(?#"):
+			// comment
+			end := strings.IndexByte(expr[i:], ')')
+			if end < 0 {
+				sb.WriteString(expr[i:])
+				end = n - i - 1
+			}
+			i += end
+
+		case strings.HasPrefix(expr[i:], "(?<=") || strings.HasPrefix(expr[i:], "(?<!"):
+			// lookbehind
+			sb.WriteString("(?:")
+			i += 3
+
+		case strings.HasPrefix(expr[i:], "(?=") || strings.HasPrefix(expr[i:], "(?!") ||
+			strings.HasPrefix(expr[i:], "(?>") || strings.HasPrefix(expr[i:], "(?|"):
+			// lookahead, atomic group or branch reset
+			sb.WriteString("(?:")
+			i += 2
+
+		case strings.HasPrefix(expr[i:], "(?'"):
+			// named group: (?'name'...)
+			if end := strings.IndexByte(expr[i+3:], '\''); end >= 0 {
+				sb.WriteString("(?:")
+				i += end + 3
+			} else {
+				sb.WriteByte(c)
+			}
+
+		case c == '+' && afterQuantifier:
+			// possessive quantifier: a*+
+
+		case c == '?' && afterQuantifier:
+			// lazy quantifier: a*?
+			sb.WriteByte(c)
+
+		case c == '*' || c == '+' || c == '?' || c == '}':
+			sb.WriteByte(c)
+			quantifier = true
+
+		default:
+			sb.WriteByte(c)
+		}
+
+		afterQuantifier = quantifier
+	}
+
+	return sb.String()
+}
+
+// PCRE accepts repeat counts up to 65535, the Go 'regexp' package only up to 1000
+func isValidPcreRepeat(expr string) bool {
+	m := repeatRegexp.FindStringSubmatch(expr)
+	if m == nil {
+		return false
+	}
+
+	lower, err := strconv.Atoi(m[1])
+	if err != nil || lower > 65535 {
+		return false
+	}
+	if m[3] == "" {
+		return true
+	}
+	upper, err := strconv.Atoi(m[3])
+	return err == nil && lower <= upper && upper <= 65535
+}
+
+// ValidateErlangRegex checks the syntax of a regular expression evaluated by RabbitMQ. The expressions which are
+// only rejected because the Go 'regexp' package doesn't support their PCRE constructs are reported as a warning.
+func ValidateErlangRegex(val interface{}, key string) (warns []string, errs []error) {
+	value, ok := val.(string)
+	if !ok {
+		errs = append(errs, fmt.Errorf("expected type of %s to be string", key))
+		return warns, errs
+	}
+
+	_, err := regexp.Compile(toGoRegexSyntax(value))
+	if err == nil {
+		return warns, errs
+	}
+
+	var syntaxErr *syntax.Error
+	if errors.As(err, &syntaxErr) && (slices.Contains(unsupportedSyntaxErrors, syntaxErr.Code) ||
+		(syntaxErr.Code == syntax.ErrInvalidRepeatSize && isValidPcreRepeat(syntaxErr.Expr))) {
+		warns = append(warns, fmt.Sprintf("%s: the syntax of %q can't be fully checked by the provider, it's checked by RabbitMQ: %s: `%s`", key, value, syntaxErr.Code, syntaxErr.Expr))
+		return warns, errs
+	}
+
+	errs = append(errs, fmt.Errorf("%s: %q is not a valid regular expression: %s", key, value, strings.TrimPrefix(err.Error(), "error parsing regexp: ")))
+	return warns, errs
+}
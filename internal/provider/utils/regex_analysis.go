@@ -0,0 +1,257 @@
+package utils
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The maximum number of states explored when two regular expressions are compared
+const regexAnalysisMaxStates = 10000
+
+// RegexOverlap reports whether a name can be matched by both regular expressions.
+// As RabbitMQ, the expressions match any part of the name when they are not anchored.
+func RegexOverlap(a string, b string) (bool, error) {
+	return exploreRegexPair(a, b, func(matchA, matchB bool) bool {
+		return matchA && matchB
+	})
+}
+
+// RegexIncludes reports whether all the names matched by the regular expression 'b' are also matched by 'a'
+func RegexIncludes(a string, b string) (bool, error) {
+	found, err := exploreRegexPair(a, b, func(matchA, matchB bool) bool {
+		return matchB && !matchA
+	})
+	return !found, err
+}
+
+// regexMachine simulates the program of a regular expression on a set of instructions.
+// The word boundaries are considered as always satisfied, so the analysis may report false overlaps for them.
+type regexMachine struct {
+	prog *syntax.Prog
+}
+
+// the state of a machine after reading a name prefix: the instructions waiting for the next rune,
+// or 'matched' when the expression matched a part of the prefix (then any suffix is accepted)
+type regexState struct {
+	pcs     []uint32
+	matched bool
+}
+
+func newRegexMachine(expr string) (*regexMachine, error) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return nil, err
+	}
+
+	return &regexMachine{prog: prog}, nil
+}
+
+// follow the instructions which don't consume a rune, return the instructions waiting for a rune
+func (m *regexMachine) closure(pcs []uint32, atStart bool, atEnd bool) (waiting []uint32, matched bool) {
+	seen := make(map[uint32]bool)
+	stack := append([]uint32{}, pcs...)
+
+	for len(stack) > 0 {
+		pc := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[pc] {
+			continue
+		}
+		seen[pc] = true
+
+		inst := &m.prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			stack = append(stack, inst.Out, inst.Arg)
+		case syntax.InstCapture, syntax.InstNop:
+			stack = append(stack, inst.Out)
+		case syntax.InstEmptyWidth:
+			op := syntax.EmptyOp(inst.Arg)
+			if op&(syntax.EmptyBeginLine|syntax.EmptyBeginText) != 0 && !atStart {
+				continue
+			}
+			if op&(syntax.EmptyEndLine|syntax.EmptyEndText) != 0 && !atEnd {
+				continue
+			}
+			stack = append(stack, inst.Out)
+		case syntax.InstMatch:
+			matched = true
+		case syntax.InstFail:
+		default:
+			waiting = append(waiting, pc)
+		}
+	}
+
+	sort.Slice(waiting, func(i, j int) bool { return waiting[i] < waiting[j] })
+	return waiting, matched
+}
+
+// read a rune from the instructions waiting for it, the search restarts at each position of the name
+func (m *regexMachine) step(waiting []uint32, r rune) regexState {
+	next := []uint32{uint32(m.prog.Start)}
+	for _, pc := range waiting {
+		inst := &m.prog.Inst[pc]
+		if matchInstRune(inst, r) {
+			next = append(next, inst.Out)
+		}
+	}
+	return regexState{pcs: next}
+}
+
+func matchInstRune(inst *syntax.Inst, r rune) bool {
+	switch inst.Op {
+	case syntax.InstRuneAny:
+		return true
+	case syntax.InstRuneAnyNotNL:
+		return r != '\n'
+	default:
+		return inst.MatchRune(r)
+	}
+}
+
+// add the runes where the behavior of an instruction changes
+func addRuneBoundaries(boundaries map[rune]bool, inst *syntax.Inst) {
+	switch inst.Op {
+	case syntax.InstRuneAnyNotNL:
+		boundaries['\n'] = true
+		boundaries['\n'+1] = true
+	case syntax.InstRune, syntax.InstRune1:
+		fold := syntax.Flags(inst.Arg)&syntax.FoldCase != 0
+		for i := 0; i+1 < len(inst.Rune) || (i == 0 && len(inst.Rune) == 1); i += 2 {
+			lo, hi := inst.Rune[i], inst.Rune[i]
+			if i+1 < len(inst.Rune) {
+				hi = inst.Rune[i+1]
+			}
+			boundaries[lo] = true
+			boundaries[hi+1] = true
+			if fold && hi-lo < 256 {
+				for r := lo; r <= hi; r++ {
+					for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+						boundaries[f] = true
+						boundaries[f+1] = true
+					}
+				}
+			}
+		}
+	}
+}
+
+func (s regexState) key() string {
+	if s.matched {
+		return "*"
+	}
+	pcs := append([]uint32{}, s.pcs...)
+	sort.Slice(pcs, func(i, j int) bool { return pcs[i] < pcs[j] })
+
+	var sb strings.Builder
+	var last uint32
+	for i, pc := range pcs {
+		if i > 0 && pc == last {
+			continue
+		}
+		sb.WriteString(strconv.FormatUint(uint64(pc), 10))
+		sb.WriteByte(',')
+		last = pc
+	}
+	return sb.String()
+}
+
+// explore the names read by both expressions until 'found' is true for a name, 'matchA' and 'matchB' tell if
+// each expression matches the name
+func exploreRegexPair(a string, b string, found func(matchA, matchB bool) bool) (bool, error) {
+	machineA, err := newRegexMachine(a)
+	if err != nil {
+		return false, err
+	}
+	machineB, err := newRegexMachine(b)
+	if err != nil {
+		return false, err
+	}
+
+	type pairState struct {
+		a, b    regexState
+		atStart bool
+	}
+
+	start := pairState{
+		a:       regexState{pcs: []uint32{uint32(machineA.prog.Start)}},
+		b:       regexState{pcs: []uint32{uint32(machineB.prog.Start)}},
+		atStart: true,
+	}
+	queue := []pairState{start}
+	visited := map[string]bool{}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		key := fmt.Sprintf("%s|%s|%t", current.a.key(), current.b.key(), current.atStart)
+		if visited[key] {
+			continue
+		}
+		visited[key] = true
+		if len(visited) > regexAnalysisMaxStates {
+			return false, fmt.Errorf("the regular expressions %q and %q are too complex to be compared", a, b)
+		}
+
+		var waitingA, waitingB []uint32
+		matchA, matchB := current.a.matched, current.b.matched
+		if !matchA {
+			waitingA, matchA = machineA.closure(current.a.pcs, current.atStart, false)
+		}
+		if !matchB {
+			waitingB, matchB = machineB.closure(current.b.pcs, current.atStart, false)
+		}
+
+		// the name may end here
+		endA, endB := matchA, matchB
+		if !endA {
+			_, endA = machineA.closure(current.a.pcs, current.atStart, true)
+		}
+		if !endB {
+			_, endB = machineB.closure(current.b.pcs, current.atStart, true)
+		}
+		if found(endA, endB) {
+			return true, nil
+		}
+
+		// any suffix is matched by both expressions, the next names give the same result
+		if matchA && matchB {
+			continue
+		}
+
+		boundaries := map[rune]bool{0: true}
+		for _, pc := range waitingA {
+			addRuneBoundaries(boundaries, &machineA.prog.Inst[pc])
+		}
+		for _, pc := range waitingB {
+			addRuneBoundaries(boundaries, &machineB.prog.Inst[pc])
+		}
+
+		for r := range boundaries {
+			if r > unicode.MaxRune {
+				continue
+			}
+
+			next := pairState{a: regexState{matched: true}, b: regexState{matched: true}}
+			if !matchA {
+				next.a = machineA.step(waitingA, r)
+			}
+			if !matchB {
+				next.b = machineB.step(waitingB, r)
+			}
+			queue = append(queue, next)
+		}
+	}
+
+	return false, nil
+}
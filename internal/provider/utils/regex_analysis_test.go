@@ -0,0 +1,83 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegexAnalysis_RegexOverlap(t *testing.T) {
+	assert := assert.New(t)
+
+	type testCaseStruct struct {
+		a        string
+		b        string
+		expected bool
+	}
+
+	for id, testCase := range map[string]testCaseStruct{
+		"Same pattern":        {a: "^orders\\.", b: "^orders\\.", expected: true},
+		"All":                 {a: ".*", b: "^orders$", expected: true},
+		"Empty":               {a: "", b: "^orders$", expected: true},
+		"Different prefixes":  {a: "^orders\\.", b: "^invoices\\.", expected: false},
+		"Prefix and suffix":   {a: "^orders\\.", b: "\\.dlq$", expected: true},
+		"Exact names":         {a: "^orders$", b: "^invoices$", expected: false},
+		"Nested prefix":       {a: "^orders", b: "^orders\\.eu", expected: true},
+		"Unanchored":          {a: "orders", b: "^invoices", expected: true},
+		"Alternation":         {a: "^(orders|invoices)$", b: "^invoices$", expected: true},
+		"Classes":             {a: "^[a-m]+$", b: "^[n-z]+$", expected: false},
+		"Overlapping classes": {a: "^[a-m]+$", b: "^[k-z]+$", expected: true},
+		"Case insensitive":    {a: "(?i)^ORDERS$", b: "^orders$", expected: true},
+		"Any character":       {a: "^a.c$", b: "^abc$", expected: true},
+		"Length":              {a: "^a{3}$", b: "^a{4}$", expected: false},
+	} {
+		t.Run(id, func(t *testing.T) {
+			result, err := utils.RegexOverlap(testCase.a, testCase.b)
+			assert.NoError(err)
+			assert.Equal(testCase.expected, result)
+		})
+	}
+}
+
+func TestRegexAnalysis_RegexIncludes(t *testing.T) {
+	assert := assert.New(t)
+
+	type testCaseStruct struct {
+		a        string
+		b        string
+		expected bool
+	}
+
+	for id, testCase := range map[string]testCaseStruct{
+		"Same pattern":        {a: "^orders\\.", b: "^orders\\.", expected: true},
+		"All":                 {a: ".*", b: "^orders$", expected: true},
+		"Empty":               {a: "", b: "^orders$", expected: true},
+		"Not all":             {a: "^orders$", b: ".*", expected: false},
+		"Shorter prefix":      {a: "^orders", b: "^orders\\.eu", expected: true},
+		"Longer prefix":       {a: "^orders\\.eu", b: "^orders", expected: false},
+		"Unanchored":          {a: "orders", b: "^orders\\.eu$", expected: true},
+		"Anchored":            {a: "^orders", b: "orders", expected: false},
+		"Alternation":         {a: "^(orders|invoices)", b: "^invoices\\.", expected: true},
+		"Classes":             {a: "^[a-z]+$", b: "^[k-m]+$", expected: true},
+		"Overlapping classes": {a: "^[a-m]+$", b: "^[k-z]+$", expected: false},
+		"Case insensitive":    {a: "(?i)^orders", b: "^ORDERS", expected: true},
+		"Case sensitive":      {a: "^orders", b: "(?i)^orders", expected: false},
+	} {
+		t.Run(id, func(t *testing.T) {
+			result, err := utils.RegexIncludes(testCase.a, testCase.b)
+			assert.NoError(err)
+			assert.Equal(testCase.expected, result)
+		})
+	}
+}
+
+func TestRegexAnalysis_InvalidRegex(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := utils.RegexOverlap("^(orders", ".*")
+	assert.Error(err)
+
+	_, err = utils.RegexIncludes(".*", "^a**$")
+	assert.Error(err)
+}
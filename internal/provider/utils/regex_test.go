@@ -0,0 +1,68 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegex_ValidateErlangRegex(t *testing.T) {
+	assert := assert.New(t)
+
+	type testCaseStruct struct {
+		input    interface{}
+		expected bool
+	}
+
+	for id, testCase := range map[string]testCaseStruct{
+		"Empty":               {input: "", expected: true},
+		"All":                 {input: ".*", expected: true},
+		"Prefix":              {input: "^amq\\.gen-.*", expected: true},
+		"Alternation":         {input: "^(orders|invoices)\\..*$", expected: true},
+		"Character class":     {input: "^[a-z]+[]0-9]*$", expected: true},
+		"POSIX class":         {input: "^[[:alnum:]_]+$", expected: true},
+		"Lookahead":           {input: "^(?!amq\\.).*", expected: true},
+		"Lookbehind":          {input: "(?<=prefix\\.)[a-z]+", expected: true},
+		"Atomic group":        {input: "(?>abc|ab)c", expected: true},
+		"Backreference":       {input: "^(a+)-\\1$", expected: true},
+		"Named backreference": {input: "^(?<id>a+)-\\k<id>$", expected: true},
+		"Possessive":          {input: "^a++b*+c?+d{2}+$", expected: true},
+		"Lazy":                {input: "^a+?b*?$", expected: true},
+		"Comment":             {input: "^abc(?#comment)$", expected: true},
+		"End of subject":      {input: "^abc\\Z", expected: true},
+		"Unbalanced group":    {input: "^(abc", expected: false},
+		"Unbalanced class":    {input: "^[abc", expected: false},
+		"Missing argument":    {input: "*abc", expected: false},
+		"Double quantifier":   {input: "^a**$", expected: false},
+		"Invalid range":       {input: "^[z-a]$", expected: false},
+		"Invalid repeat":      {input: "^a{2,1}$", expected: false},
+		"Too large repeat":    {input: "^a{70000}$", expected: false},
+		"Not a string":        {input: 42, expected: false},
+	} {
+		t.Run(id, func(t *testing.T) {
+			_, errs := utils.ValidateErlangRegex(testCase.input, "read")
+
+			assert.Equal(testCase.expected, len(errs) == 0, errs)
+		})
+	}
+}
+
+func TestRegex_ValidateErlangRegexWarnings(t *testing.T) {
+	assert := assert.New(t)
+
+	for id, input := range map[string]string{
+		"Large repeat":       "^a{1001}$",
+		"Large repeat range": "^a{2,5000}$",
+		"Conditional":        "^(a)?(?(1)b|c)$",
+		"Callout":            "^(?C1)abc$",
+		"Octal escape":       "^\\o{101}$",
+	} {
+		t.Run(id, func(t *testing.T) {
+			warns, errs := utils.ValidateErlangRegex(input, "read")
+
+			assert.Empty(errs)
+			assert.Len(warns, 1)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
+	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider"
 	"github.com/rfd59/terraform-provider-rabbitmq/internal/provider/utils"
 )
 
@@ -132,7 +133,7 @@ func (e *ExchangeResource) DataSource(data TestData) string {
 }
 
 func (e ExchangeResource) ExistsInRabbitMQ(argsChecked bool) (*rabbithole.DetailedExchangeInfo, error) {
-	rmqc := TestAcc.Provider.Meta().(*rabbithole.Client)
+	rmqc := TestAcc.Provider.Meta().(*provider.Meta).Client
 	myExchange, err := rmqc.GetExchange(e.Vhost, e.Name)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving exchange '%s': %#v", e.Name, err)
@@ -174,8 +175,14 @@ func (e ExchangeResource) ExistsInRabbitMQ(argsChecked bool) (*rabbithole.Detail
 			}
 
 			for _, v := range e.Arguments {
-				if myExchange.Arguments[v["key"].(string)] != v["value"] {
-					return nil, fmt.Errorf("exchange argument %q is not equal: expected: '%s', got '%s'", v["key"], v["value"], myExchange.Arguments[v["key"].(string)])
+				expected := v["value"]
+				if _, ok := expected.(string); ok {
+					if value, err := utils.GetArgumentValue(v); err == nil {
+						expected = value
+					}
+				}
+				if !utils.EqualArgumentValues(myExchange.Arguments[v["key"].(string)], expected) {
+					return nil, fmt.Errorf("exchange argument %q is not equal: expected: '%v', got '%v'", v["key"], expected, myExchange.Arguments[v["key"].(string)])
 				}
 			}
 		}
@@ -186,7 +193,7 @@ func (e ExchangeResource) ExistsInRabbitMQ(argsChecked bool) (*rabbithole.Detail
 
 func (e *ExchangeResource) CheckDestroy() resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := TestAcc.Provider.Meta().(*rabbithole.Client)
+		rmqc := TestAcc.Provider.Meta().(*provider.Meta).Client
 		exchange, err := rmqc.GetExchange(e.Vhost, e.Name)
 		if err != nil && err.(rabbithole.ErrorResponse).StatusCode != 404 {
 			return fmt.Errorf("error retrieving exchange '%s': %#v", e.Name, err)
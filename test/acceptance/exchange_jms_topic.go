@@ -0,0 +1,47 @@
+package acceptance_test
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+type ExchangeJmsTopicResource struct {
+	ExchangeResource
+}
+
+func (e ExchangeJmsTopicResource) ExistsInRabbitMQ() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, err := e.ExchangeResource.ExistsInRabbitMQ(true); err != nil {
+			return err
+		} else {
+			return nil
+		}
+	}
+}
+
+func (e ExchangeJmsTopicResource) BindingSelector(data TestData, selector string) string {
+	return fmt.Sprintf(`
+	resource "%s" "%s" {
+		name = "%s"
+	}
+
+	resource "rabbitmq_queue" "test" {
+		name = "%s"
+		vhost = "/"
+		settings {
+			durable = false
+			auto_delete = true
+		}
+	}
+
+	resource "rabbitmq_binding" "test" {
+		source = %s.name
+		vhost = "/"
+		destination = rabbitmq_queue.test.name
+		destination_type = "queue"
+		routing_key = "#"
+		jms_topic_selector = %q
+	}`, data.ResourceType, data.ResourceLabel, e.Name, data.RandomString(), data.ResourceName, selector)
+}
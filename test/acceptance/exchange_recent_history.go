@@ -0,0 +1,30 @@
+package acceptance_test
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+type ExchangeRecentHistoryResource struct {
+	ExchangeResource
+}
+
+func (e ExchangeRecentHistoryResource) ExistsInRabbitMQ() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, err := e.ExchangeResource.ExistsInRabbitMQ(true); err != nil {
+			return err
+		} else {
+			return nil
+		}
+	}
+}
+
+func (e ExchangeRecentHistoryResource) OptionalHistoryLength(data TestData, length int) string {
+	return fmt.Sprintf(`
+	resource "%s" "%s" {
+		name = "%s"
+		history_length = %d
+	}`, data.ResourceType, data.ResourceLabel, e.Name, length)
+}